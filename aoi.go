@@ -0,0 +1,505 @@
+package geo
+
+import (
+	"slices"
+
+	"github.com/wildmap/utility"
+)
+
+// AOIListener 接收视野（Area of Interest）变化事件，watcher 为观察者，target 为被观察的实体。
+type AOIListener interface {
+	// OnEnter 在 target 进入 watcher 的视野时调用
+	OnEnter(watcher, target int64)
+	// OnLeave 在 target 离开 watcher 的视野时调用
+	OnLeave(watcher, target int64)
+	// OnMove 在 target 于 watcher 视野内从 from 移动到 to 时调用
+	OnMove(watcher, target int64, from, to Coord)
+}
+
+// AOIStrategy 表示 AOI 管理器使用的坐标点空间索引策略。
+// 查询均以某个已登记实体为锚点，便于十字链表等基于邻接遍历的实现。
+type AOIStrategy interface {
+	// Add 登记实体及其坐标
+	Add(id int64, p Coord)
+	// Remove 移除实体
+	Remove(id int64)
+	// Move 更新实体坐标
+	Move(id int64, p Coord)
+	// Around 返回与实体距离不超过 radius 的其它实体（含边界，不含自身），顺序不作保证
+	Around(id int64, radius int32) []int64
+}
+
+// aoiEntity 表示 AOI 管理器中登记的实体。
+type aoiEntity struct {
+	view     Circle         // 视野圆：圆心为实体坐标，半径为视野半径
+	watching map[int64]bool // 当前位于本实体视野内的实体
+	watchers map[int64]bool // 当前视野内能看到本实体的实体
+}
+
+// AOIManager 在坐标点空间索引之上维护实体之间的可见关系，并在实体进入、离开、移动时派发事件。
+// 实体 A 能看到实体 B 当且仅当 B 位于 A 的视野圆内（含边界），各实体视野半径可以不同。
+//
+// Move 立即生效；QueueMove 仅记录目标位置，同一实体多次排队以最后一次为准，
+// 由 Tick 统一提交：先更新全部坐标，再重算可见关系，避免同一帧内的中间状态产生多余事件。
+// 事件按实体序号升序派发，相同输入得到相同的事件序列。
+type AOIManager struct {
+	strategy AOIStrategy
+	listener AOIListener
+	entities map[int64]*aoiEntity
+	radii    map[int32]int   // 视野半径 → 使用该半径的实体数，用于求最大视野半径
+	pending  map[int64]Coord // 等待 Tick 提交的移动
+	queue    []int64         // 按排队顺序记录的待移动实体
+}
+
+// NewAOIManager 以空间索引策略与事件监听者创建 AOI 管理器，listener 可为 nil。
+func NewAOIManager(strategy AOIStrategy, listener AOIListener) *AOIManager {
+	return &AOIManager{
+		strategy: strategy,
+		listener: listener,
+		entities: make(map[int64]*aoiEntity),
+		radii:    make(map[int32]int),
+		pending:  make(map[int64]Coord),
+	}
+}
+
+// Len 返回登记的实体数量。
+func (m *AOIManager) Len() int {
+	return len(m.entities)
+}
+
+// Enter 登记实体，view 的圆心为实体坐标、半径为视野半径。
+// 实体与周围实体建立双向的可见关系并派发 OnEnter 事件。实体已存在时返回 false。
+func (m *AOIManager) Enter(id int64, view Circle) bool {
+	if _, ok := m.entities[id]; ok {
+		return false
+	}
+	m.entities[id] = &aoiEntity{
+		view:     view,
+		watching: make(map[int64]bool),
+		watchers: make(map[int64]bool),
+	}
+	m.radii[view.Radius]++
+	m.strategy.Add(id, view.Center)
+	m.refresh(id)
+	return true
+}
+
+// Leave 移除实体并解除其全部可见关系：先对其视野内的实体、再对能看到它的实体派发 OnLeave 事件，
+// 保证每个 OnEnter 都有对应的 OnLeave。实体不存在时返回 false。
+func (m *AOIManager) Leave(id int64) bool {
+	e, ok := m.entities[id]
+	if !ok {
+		return false
+	}
+	for _, t := range sortedIDs(e.watching) {
+		delete(m.entities[t].watchers, id)
+		m.emitLeave(id, t)
+	}
+	for _, w := range sortedIDs(e.watchers) {
+		delete(m.entities[w].watching, id)
+		m.emitLeave(w, id)
+	}
+	m.strategy.Remove(id)
+	m.releaseRadius(e.view.Radius)
+	delete(m.entities, id)
+	m.cancelPending(id)
+	return true
+}
+
+// View 返回实体当前的视野圆。
+func (m *AOIManager) View(id int64) (Circle, bool) {
+	e, ok := m.entities[id]
+	if !ok {
+		return Circle{}, false
+	}
+	return e.view, true
+}
+
+// Watching 返回位于实体视野内的实体序号（升序）。
+func (m *AOIManager) Watching(id int64) []int64 {
+	if e, ok := m.entities[id]; ok {
+		return sortedIDs(e.watching)
+	}
+	return nil
+}
+
+// Watchers 返回能看到该实体的实体序号（升序）。
+func (m *AOIManager) Watchers(id int64) []int64 {
+	if e, ok := m.entities[id]; ok {
+		return sortedIDs(e.watchers)
+	}
+	return nil
+}
+
+// SetViewRadius 修改实体的视野半径，并对进出视野的实体派发 OnEnter、OnLeave 事件。
+func (m *AOIManager) SetViewRadius(id int64, radius int32) bool {
+	e, ok := m.entities[id]
+	if !ok {
+		return false
+	}
+	if e.view.Radius == radius {
+		return true
+	}
+	m.releaseRadius(e.view.Radius)
+	m.radii[radius]++
+	e.view.Radius = radius
+	m.refresh(id)
+	return true
+}
+
+// Move 立即将实体移动到 p，并取消该实体在本帧排队的移动。
+func (m *AOIManager) Move(id int64, p Coord) bool {
+	if _, ok := m.entities[id]; !ok {
+		return false
+	}
+	m.cancelPending(id)
+	m.commit([]int64{id}, map[int64]Coord{id: p})
+	return true
+}
+
+// QueueMove 记录实体在本帧的目标位置，直到 Tick 时才生效。
+func (m *AOIManager) QueueMove(id int64, p Coord) bool {
+	if _, ok := m.entities[id]; !ok {
+		return false
+	}
+	if _, ok := m.pending[id]; !ok {
+		m.queue = append(m.queue, id)
+	}
+	m.pending[id] = p
+	return true
+}
+
+// cancelPending 取消实体在本帧排队的移动。
+func (m *AOIManager) cancelPending(id int64) {
+	if _, ok := m.pending[id]; ok {
+		delete(m.pending, id)
+		m.queue = slices.DeleteFunc(m.queue, func(q int64) bool { return q == id })
+	}
+}
+
+// Tick 提交本帧排队的全部移动并派发事件。
+func (m *AOIManager) Tick() {
+	if len(m.queue) == 0 {
+		return
+	}
+	queue, pending := m.queue, m.pending
+	m.queue, m.pending = nil, make(map[int64]Coord)
+	m.commit(queue, pending)
+}
+
+// commit 批量移动实体：先更新全部坐标，再逐个重算可见关系派发进出事件，
+// 最后对移动前后始终能看到移动实体的观察者派发 OnMove 事件。
+func (m *AOIManager) commit(ids []int64, targets map[int64]Coord) {
+	from := make(map[int64]Coord, len(ids))
+	watchers := make(map[int64][]int64, len(ids))
+	for _, id := range ids {
+		e := m.entities[id]
+		from[id] = e.view.Center
+		watchers[id] = sortedIDs(e.watchers)
+		e.view.Center = targets[id]
+		m.strategy.Move(id, e.view.Center)
+	}
+	for _, id := range ids {
+		m.refresh(id)
+	}
+	for _, id := range ids {
+		e := m.entities[id]
+		if from[id] == e.view.Center {
+			continue
+		}
+		for _, w := range watchers[id] {
+			if e.watchers[w] {
+				m.emitMove(w, id, from[id], e.view.Center)
+			}
+		}
+	}
+}
+
+// refresh 重算实体与周围实体之间的双向可见关系，并派发进出视野事件。
+// 查询半径取全部实体的最大视野半径，以找出所有可能看到该实体的观察者。
+func (m *AOIManager) refresh(id int64) {
+	e := m.entities[id]
+	watching := make(map[int64]bool)
+	watchers := make(map[int64]bool)
+	radius := max(e.view.Radius, m.maxRadius())
+	for _, o := range m.strategy.Around(id, radius) {
+		other := m.entities[o]
+		dist := CalDstCoordToCoordWithoutSqrt(e.view.Center, other.view.Center)
+		if dist <= float64(e.view.Radius)*float64(e.view.Radius) {
+			watching[o] = true
+		}
+		if dist <= float64(other.view.Radius)*float64(other.view.Radius) {
+			watchers[o] = true
+		}
+	}
+
+	for _, t := range sortedIDs(e.watching) {
+		if !watching[t] {
+			delete(e.watching, t)
+			delete(m.entities[t].watchers, id)
+			m.emitLeave(id, t)
+		}
+	}
+	for _, w := range sortedIDs(e.watchers) {
+		if !watchers[w] {
+			delete(e.watchers, w)
+			delete(m.entities[w].watching, id)
+			m.emitLeave(w, id)
+		}
+	}
+	for _, t := range sortedIDs(watching) {
+		if !e.watching[t] {
+			e.watching[t] = true
+			m.entities[t].watchers[id] = true
+			m.emitEnter(id, t)
+		}
+	}
+	for _, w := range sortedIDs(watchers) {
+		if !e.watchers[w] {
+			e.watchers[w] = true
+			m.entities[w].watching[id] = true
+			m.emitEnter(w, id)
+		}
+	}
+}
+
+// maxRadius 返回当前全部实体中的最大视野半径。
+func (m *AOIManager) maxRadius() int32 {
+	var r int32
+	for radius := range m.radii {
+		r = max(r, radius)
+	}
+	return r
+}
+
+// releaseRadius 减少视野半径的引用计数，计数归零时删除。
+func (m *AOIManager) releaseRadius(radius int32) {
+	if m.radii[radius]--; m.radii[radius] <= 0 {
+		delete(m.radii, radius)
+	}
+}
+
+func (m *AOIManager) emitEnter(watcher, target int64) {
+	if m.listener != nil {
+		m.listener.OnEnter(watcher, target)
+	}
+}
+
+func (m *AOIManager) emitLeave(watcher, target int64) {
+	if m.listener != nil {
+		m.listener.OnLeave(watcher, target)
+	}
+}
+
+func (m *AOIManager) emitMove(watcher, target int64, from, to Coord) {
+	if m.listener != nil {
+		m.listener.OnMove(watcher, target, from, to)
+	}
+}
+
+// sortedIDs 返回集合中的实体序号（升序）。
+func sortedIDs(set map[int64]bool) []int64 {
+	ids := make([]int64, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// GridAOI 是基于 GridIndex 的 AOI 策略，格子边长宜取常用视野半径附近的值。
+// 查询代价与视野覆盖的格子数及格内实体数成正比，适合实体分布较均匀的大地图。
+type GridAOI struct {
+	grid *GridIndex[int64]
+}
+
+// NewGridAOI 以格子边长与世界宽高创建网格 AOI 策略。
+func NewGridAOI(cellSize, width, height int32) *GridAOI {
+	return &GridAOI{grid: NewGridIndex[int64](cellSize, cellSize, width, height)}
+}
+
+// Add 登记实体及其坐标。
+func (g *GridAOI) Add(id int64, p Coord) {
+	g.grid.Insert(id, p)
+}
+
+// Remove 移除实体。
+func (g *GridAOI) Remove(id int64) {
+	g.grid.Remove(id)
+}
+
+// Move 更新实体坐标，同格移动不触碰格子存储。
+func (g *GridAOI) Move(id int64, p Coord) {
+	g.grid.Move(id, p)
+}
+
+// Around 返回与实体距离不超过 radius 的其它实体。
+func (g *GridAOI) Around(id int64, radius int32) []int64 {
+	rect, ok := g.grid.Bounds(id)
+	if !ok {
+		return nil
+	}
+	ret := g.grid.QueryCircle(NewCirCle(rect.Coord, radius))
+	return slices.DeleteFunc(ret, func(o int64) bool { return o == id })
+}
+
+// crossNode 表示十字链表中的实体节点，同时挂在 X 轴链表与 Z 轴链表上。
+type crossNode struct {
+	id         int64
+	coord      Coord
+	prev, next [2]*crossNode // 下标 0 为 X 轴链表，1 为 Z 轴链表
+}
+
+// axis 返回节点在指定轴上的坐标值。
+func (n *crossNode) axis(i int) int32 {
+	if i == 0 {
+		return n.coord.X
+	}
+	return n.coord.Z
+}
+
+// CrossListAOI 是基于十字链表的 AOI 策略：实体分别按 X、Z 坐标串在两条有序双向链表上。
+// 移动时节点沿链表向相邻位置冒泡，移动距离小时开销极低；
+// 查询时从锚点实体沿两条链表同时向两侧展开，先在窗口内走完的轴给出候选集合。
+// 不需要预设地图尺寸，适合实体数量适中、移动频繁且步长较小的场景。
+type CrossListAOI struct {
+	head  [2]*crossNode
+	nodes map[int64]*crossNode
+}
+
+// NewCrossListAOI 创建十字链表 AOI 策略。
+func NewCrossListAOI() *CrossListAOI {
+	return &CrossListAOI{nodes: make(map[int64]*crossNode)}
+}
+
+// Add 登记实体，从链表头部查找插入位置。实体已存在时等价于 Move。
+func (c *CrossListAOI) Add(id int64, p Coord) {
+	if _, ok := c.nodes[id]; ok {
+		c.Move(id, p)
+		return
+	}
+	n := &crossNode{id: id, coord: p}
+	c.nodes[id] = n
+	for i := range c.head {
+		var prev *crossNode
+		cur := c.head[i]
+		for cur != nil && cur.axis(i) < n.axis(i) {
+			prev, cur = cur, cur.next[i]
+		}
+		c.link(i, n, prev, cur)
+	}
+}
+
+// Remove 从两条链表中摘除实体。
+func (c *CrossListAOI) Remove(id int64) {
+	n, ok := c.nodes[id]
+	if !ok {
+		return
+	}
+	for i := range c.head {
+		c.unlink(i, n)
+	}
+	delete(c.nodes, id)
+}
+
+// Move 更新实体坐标，并沿两条链表将节点移动到新的有序位置。
+func (c *CrossListAOI) Move(id int64, p Coord) {
+	n, ok := c.nodes[id]
+	if !ok {
+		return
+	}
+	n.coord = p
+	for i := range c.head {
+		if prev := n.prev[i]; prev != nil && prev.axis(i) > n.axis(i) {
+			// 向链表头方向冒泡，插入到最后一个越过的节点之前
+			last := prev
+			for prev != nil && prev.axis(i) > n.axis(i) {
+				last, prev = prev, prev.prev[i]
+			}
+			c.unlink(i, n)
+			c.link(i, n, last.prev[i], last)
+		} else if next := n.next[i]; next != nil && next.axis(i) < n.axis(i) {
+			// 向链表尾方向冒泡，插入到最后一个越过的节点之后
+			last := next
+			for next != nil && next.axis(i) < n.axis(i) {
+				last, next = next, next.next[i]
+			}
+			c.unlink(i, n)
+			c.link(i, n, last, last.next[i])
+		}
+	}
+}
+
+// Around 返回与实体距离不超过 radius 的其它实体。
+// 两条链表上的四个游标轮流推进一步，某条轴的两个游标均越出 [v-radius, v+radius] 窗口时，
+// 该轴已遍历的节点即为全部候选，再按距离精确过滤。
+func (c *CrossListAOI) Around(id int64, radius int32) []int64 {
+	n, ok := c.nodes[id]
+	if !ok {
+		return nil
+	}
+	var visited [2][]*crossNode
+	var cursors [2][2]*crossNode // [轴][0 向前, 1 向后]
+	for i := range cursors {
+		cursors[i] = [2]*crossNode{n.prev[i], n.next[i]}
+	}
+	inWindow := func(i int, o *crossNode) bool {
+		return o != nil && utility.Abs(int64(o.axis(i))-int64(n.axis(i))) <= int64(radius)
+	}
+	done := -1
+	for done < 0 {
+		for i := range cursors {
+			stepped := false
+			for d := range cursors[i] {
+				if o := cursors[i][d]; inWindow(i, o) {
+					visited[i] = append(visited[i], o)
+					if d == 0 {
+						cursors[i][d] = o.prev[i]
+					} else {
+						cursors[i][d] = o.next[i]
+					}
+					stepped = true
+				}
+			}
+			if !stepped {
+				done = i
+				break
+			}
+		}
+	}
+
+	radiusSquared := float64(radius) * float64(radius)
+	var ret []int64
+	for _, o := range visited[done] {
+		if CalDstCoordToCoordWithoutSqrt(n.coord, o.coord) <= radiusSquared {
+			ret = append(ret, o.id)
+		}
+	}
+	return ret
+}
+
+// link 将节点插入到指定轴链表的 prev 与 next 之间。
+func (c *CrossListAOI) link(i int, n, prev, next *crossNode) {
+	n.prev[i], n.next[i] = prev, next
+	if prev != nil {
+		prev.next[i] = n
+	} else {
+		c.head[i] = n
+	}
+	if next != nil {
+		next.prev[i] = n
+	}
+}
+
+// unlink 将节点从指定轴链表中摘除。
+func (c *CrossListAOI) unlink(i int, n *crossNode) {
+	if n.prev[i] != nil {
+		n.prev[i].next[i] = n.next[i]
+	} else {
+		c.head[i] = n.next[i]
+	}
+	if n.next[i] != nil {
+		n.next[i].prev[i] = n.prev[i]
+	}
+	n.prev[i], n.next[i] = nil, nil
+}
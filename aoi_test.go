@@ -0,0 +1,191 @@
+package geo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// aoiRecorder 按派发顺序记录 AOI 事件。
+type aoiRecorder struct {
+	events []string
+}
+
+func (r *aoiRecorder) OnEnter(watcher, target int64) {
+	r.events = append(r.events, fmt.Sprintf("enter %d %d", watcher, target))
+}
+
+func (r *aoiRecorder) OnLeave(watcher, target int64) {
+	r.events = append(r.events, fmt.Sprintf("leave %d %d", watcher, target))
+}
+
+func (r *aoiRecorder) OnMove(watcher, target int64, from, to Coord) {
+	r.events = append(r.events, fmt.Sprintf("move %d %d %v %v", watcher, target, from, to))
+}
+
+// aoiStrategies 返回待测试的全部空间索引策略。
+func aoiStrategies() map[string]func() AOIStrategy {
+	return map[string]func() AOIStrategy{
+		"grid":       func() AOIStrategy { return NewGridAOI(200, 5000, 5000) },
+		"cross list": func() AOIStrategy { return NewCrossListAOI() },
+	}
+}
+
+func TestAOIManagerEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(m *AOIManager)
+		want []string
+	}{
+		{
+			name: "move into view",
+			ops:  func(m *AOIManager) { m.Move(3, Coord{80, 0}) },
+			want: []string{"enter 3 1", "enter 3 2", "enter 1 3", "enter 2 3"},
+		},
+		{
+			name: "move within view",
+			ops:  func(m *AOIManager) { m.Move(2, Coord{60, 0}) },
+			want: []string{"move 1 2 {50 0} {60 0}"},
+		},
+		{
+			name: "move out of view",
+			ops:  func(m *AOIManager) { m.Move(2, Coord{200, 0}) },
+			want: []string{"leave 1 2"},
+		},
+		{
+			name: "leave",
+			ops:  func(m *AOIManager) { m.Leave(1) },
+			want: []string{"leave 1 2"},
+		},
+		{
+			name: "set view radius",
+			ops:  func(m *AOIManager) { m.SetViewRadius(2, 60) },
+			want: []string{"enter 2 1"},
+		},
+		{
+			name: "queued moves keep the last target",
+			ops: func(m *AOIManager) {
+				m.QueueMove(2, Coord{200, 0})
+				m.QueueMove(2, Coord{60, 0})
+				m.Tick()
+			},
+			want: []string{"move 1 2 {50 0} {60 0}"},
+		},
+		{
+			name: "move cancels queued move",
+			ops: func(m *AOIManager) {
+				m.QueueMove(2, Coord{400, 0})
+				m.Move(2, Coord{60, 0})
+				m.Tick()
+			},
+			want: []string{"move 1 2 {50 0} {60 0}"},
+		},
+		{
+			name: "swap in one tick",
+			ops: func(m *AOIManager) {
+				m.QueueMove(3, Coord{50, 0})
+				m.QueueMove(2, Coord{500, 0})
+				m.Tick()
+			},
+			want: []string{"enter 3 1", "enter 1 3", "leave 1 2"},
+		},
+	}
+	for name, strategy := range aoiStrategies() {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				r := &aoiRecorder{}
+				m := NewAOIManager(strategy(), r)
+				m.Enter(1, NewCirCle(Coord{0, 0}, 100))
+				m.Enter(2, NewCirCle(Coord{50, 0}, 30))
+				m.Enter(3, NewCirCle(Coord{500, 0}, 100))
+				if want := []string{"enter 1 2"}; !slices.Equal(r.events, want) {
+					t.Fatalf("setup events = %v, want %v", r.events, want)
+				}
+				r.events = nil
+				tt.ops(m)
+				if !slices.Equal(r.events, tt.want) {
+					t.Fatalf("events = %v, want %v", r.events, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestAOIManagerMatchesBruteForce(t *testing.T) {
+	for name, strategy := range aoiStrategies() {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			r := &aoiRecorder{}
+			m := NewAOIManager(strategy(), r)
+			pos := map[int64]Coord{}
+			radius := map[int64]int32{}
+			check := func() {
+				t.Helper()
+				for a, pa := range pos {
+					var want []int64
+					for b, pb := range pos {
+						if a != b && CalDstCoordToCoordWithoutSqrt(pa, pb) <= float64(radius[a])*float64(radius[a]) {
+							want = append(want, b)
+						}
+					}
+					slices.Sort(want)
+					if got := m.Watching(a); !slices.Equal(got, want) {
+						t.Fatalf("Watching(%d) = %v, want %v", a, got, want)
+					}
+				}
+			}
+			for i := range int64(150) {
+				p := Coord{rng.Int32N(4000), rng.Int32N(4000)}
+				radius[i], pos[i] = 100+rng.Int32N(400), p
+				m.Enter(i, NewCirCle(p, radius[i]))
+			}
+			check()
+			for step := range 50 {
+				for j := range 40 {
+					id := rng.Int64N(150)
+					p, ok := pos[id]
+					if !ok {
+						continue
+					}
+					p.X = max(0, min(4999, p.X+rng.Int32N(400)-200))
+					p.Z = max(0, min(4999, p.Z+rng.Int32N(400)-200))
+					if j%2 == 0 {
+						m.Move(id, p)
+					} else {
+						m.QueueMove(id, p)
+					}
+					pos[id] = p
+				}
+				m.Tick()
+				switch step % 10 {
+				case 3:
+					if id := rng.Int64N(150); m.Leave(id) {
+						delete(pos, id)
+					}
+				case 5:
+					if id, rad := rng.Int64N(150), 50+rng.Int32N(600); m.SetViewRadius(id, rad) {
+						radius[id] = rad
+					}
+				}
+				check()
+			}
+			// 每个 OnEnter 都有对应的 OnLeave：全部离开后进入与离开事件数相等
+			for id := range pos {
+				m.Leave(id)
+			}
+			enter, leave := 0, 0
+			for _, e := range r.events {
+				switch e[:5] {
+				case "enter":
+					enter++
+				case "leave":
+					leave++
+				}
+			}
+			if enter != leave || m.Len() != 0 {
+				t.Fatalf("enter = %d, leave = %d, Len() = %d", enter, leave, m.Len())
+			}
+		})
+	}
+}
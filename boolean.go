@@ -0,0 +1,566 @@
+package geo
+
+import (
+	"cmp"
+	"math/big"
+	"slices"
+)
+
+// BooleanOp 表示多边形布尔运算的类型。
+type BooleanOp int8
+
+const (
+	BooleanUnion        BooleanOp = iota // 并集 A ∪ B
+	BooleanIntersection                  // 交集 A ∩ B
+	BooleanDifference                    // 差集 A − B
+	BooleanXor                           // 对称差 (A − B) ∪ (B − A)
+)
+
+// apply 根据点是否在 A、B 内判断其是否在运算结果内。
+func (op BooleanOp) apply(inA, inB bool) bool {
+	switch op {
+	case BooleanUnion:
+		return inA || inB
+	case BooleanIntersection:
+		return inA && inB
+	case BooleanDifference:
+		return inA && !inB
+	case BooleanXor:
+		return inA != inB
+	}
+	return false
+}
+
+// Union 计算两组多边形的并集，见 Boolean。
+func Union(a, b []*SimplePolygon) []*SimplePolygon {
+	return Boolean(BooleanUnion, a, b)
+}
+
+// Intersection 计算两组多边形的交集，见 Boolean。
+func Intersection(a, b []*SimplePolygon) []*SimplePolygon {
+	return Boolean(BooleanIntersection, a, b)
+}
+
+// Difference 计算 a 减去 b 的差集，见 Boolean。
+func Difference(a, b []*SimplePolygon) []*SimplePolygon {
+	return Boolean(BooleanDifference, a, b)
+}
+
+// Xor 计算两组多边形的对称差，见 Boolean。
+func Xor(a, b []*SimplePolygon) []*SimplePolygon {
+	return Boolean(BooleanXor, a, b)
+}
+
+// Boolean 对两组多边形（subject 与 clip，每组内的多边形可相互重叠）执行布尔运算，
+// 用于领地合并、迷雾揭示等需要组合区域的场景。各环方向不限，每组按非零环绕规则确定覆盖区域。
+//
+// 算法基于边分割与分类（与 Martinez 算法思路一致）：
+//  1. 以扫描线求出所有环边的交点，按热像素吸附分割各边（见 splitRings）；
+//  2. 对每条子边，分别判断其左右两侧是否位于 subject 与 clip 内，按 op 求出两侧是否在结果内，
+//     两侧结果不同的子边即为结果边界，并定向为结果区域在其左侧；
+//  3. 在每个顶点处取最靠左的出边将边界边串接成环，逆时针环为外环，顺时针环为洞，
+//     洞归属于包含它的最小外环。
+//
+// 所有方向判定均为整数精确计算（子边两侧以符号扰动代替浮点偏移），
+// 只有真正相交（非端点）的交点需要构造新坐标，按四舍五入取整；经过取整交点所在像素的边一并吸附到该点，
+// 因此取整不会引入新的相交，结果在交点附近的偏移不超过 1 个单位。
+//
+// 返回的多边形外环逆时针、洞顺时针，共线顶点已被移除，Index 按输出顺序从 0 开始，EdgeIDs 为空。
+// 仅在顶点处接触的区域输出为各自独立的多边形。
+func Boolean(op BooleanOp, subject, clip []*SimplePolygon) []*SimplePolygon {
+	groups := [][][]Coord{booleanRings(subject), booleanRings(clip)}
+	return overlay(groups, func(wn []int) bool {
+		return op.apply(wn[0] != 0, wn[1] != 0)
+	})
+}
+
+// overlay 对多组环执行叠加运算：分割所有环边后，按 fill 判断每条子边两侧是否位于结果内，
+// 保留两侧结果不同的子边并串接成环，组装为多边形。fill 的参数为测试点相对于各组环的环绕数。
+func overlay(groups [][][]Coord, fill func(wn []int) bool) []*SimplePolygon {
+	var rings [][]Coord
+	var owners []int
+	for g, group := range groups {
+		for _, r := range group {
+			rings = append(rings, r)
+			owners = append(owners, g)
+		}
+	}
+	rings = splitRings(rings)
+
+	// 按所属分组收集有向子边，并按首次出现的顺序收集无向子边
+	grouped := make([][]boolEdge, len(groups))
+	var edges []boolEdge
+	seen := make(map[boolEdge]bool)
+	for k, ring := range rings {
+		for i := range ring {
+			e := boolEdge{a: ring[i], b: ring[(i+1)%len(ring)]}
+			grouped[owners[k]] = append(grouped[owners[k]], e)
+			if key := e.canonical(); !seen[key] {
+				seen[key] = true
+				edges = append(edges, key)
+			}
+		}
+	}
+
+	// 保留两侧结果不同的子边，并定向为结果区域在左侧
+	wn := make([]int, len(groups))
+	side := func(e boolEdge, left bool) bool {
+		for g, group := range grouped {
+			wn[g] = windingBeside(group, e, left)
+		}
+		return fill(wn)
+	}
+	var result []boolEdge
+	for _, e := range edges {
+		left, right := side(e, true), side(e, false)
+		switch {
+		case left && !right:
+			result = append(result, e)
+		case right && !left:
+			result = append(result, boolEdge{a: e.b, b: e.a})
+		}
+	}
+	return assembleRings(traceRings(result))
+}
+
+// boolEdge 表示布尔运算中的一条有向边 a→b。
+type boolEdge struct {
+	a, b Coord
+}
+
+// canonical 返回端点按字典序排列的无向边表示。
+func (e boolEdge) canonical() boolEdge {
+	if e.b.X < e.a.X || e.b.X == e.a.X && e.b.Z < e.a.Z {
+		return boolEdge{a: e.b, b: e.a}
+	}
+	return e
+}
+
+// booleanRings 清理并统一各多边形的环方向（外环逆时针、洞顺时针），丢弃无效环，不修改原多边形。
+func booleanRings(polygons []*SimplePolygon) [][]Coord {
+	var rings [][]Coord
+	for _, p := range polygons {
+		outer := normalizeRing(p.Outer, true)
+		if outer == nil {
+			continue
+		}
+		rings = append(rings, outer)
+		for _, h := range p.Holes {
+			if hole := normalizeRing(h, false); hole != nil {
+				rings = append(rings, hole)
+			}
+		}
+	}
+	return rings
+}
+
+// splitRings 以热像素吸附（snap rounding）分割所有环边，使任意两条子边只在端点处接触或完全重合：
+//  1. 按 X 排序扫描各边的包围盒，求出真正相交（非端点）的边对的交点并四舍五入取整，
+//     连同全部顶点作为热像素（以整数坐标为中心、边长为 1 的闭正方形）；
+//  2. 每条边经过的全部热像素的中心按沿边的顺序插入该边，边被吸附为经过这些中心的折线。
+//
+// 吸附后的子边不会产生新的相交（Hobby、Guibas–Marimont），因此一轮即可完成，无需反复分割；
+// 每个顶点至多偏离原边半个像素对角线的距离。
+func splitRings(rings [][]Coord) [][]Coord {
+	type ringEdge struct {
+		a, b Coord
+	}
+	var edges []ringEdge
+	var hot []Coord
+	for _, ring := range rings {
+		for i := range ring {
+			edges = append(edges, ringEdge{a: ring[i], b: ring[(i+1)%len(ring)]})
+			hot = append(hot, ring[i])
+		}
+	}
+
+	// 扫描线：按包围盒左端排序，只检查 X 区间重叠的边对
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		return cmp.Compare(min(edges[i].a.X, edges[i].b.X), min(edges[j].a.X, edges[j].b.X))
+	})
+	for k, i := range order {
+		e1 := edges[i]
+		right := max(e1.a.X, e1.b.X)
+		for _, j := range order[k+1:] {
+			e2 := edges[j]
+			if min(e2.a.X, e2.b.X) > right {
+				break
+			}
+			if p, ok := properCrossCoord(e1.a, e1.b, e2.a, e2.b); ok {
+				hot = append(hot, p)
+			}
+		}
+	}
+	slices.SortFunc(hot, func(p, q Coord) int {
+		return cmp.Or(cmp.Compare(p.X, q.X), cmp.Compare(p.Z, q.Z))
+	})
+	hot = slices.Compact(hot)
+
+	ret := make([][]Coord, 0, len(rings))
+	k := 0
+	for _, ring := range rings {
+		var split []Coord
+		for range ring {
+			e := edges[k]
+			k++
+			split = append(split, e.a)
+			split = append(split, snapHotPixels(e.a, e.b, hot)...)
+		}
+		ret = append(ret, dedupeRing(split))
+	}
+	return ret
+}
+
+// properCrossCoord 求线段 ab 与 cd 真正相交（交点不是任一线段的端点，且两线段不共线）时取整后的交点。
+// 端点接触与共线重叠不需要新坐标，端点本身已是热像素。
+func properCrossCoord(a, b, c, d Coord) (Coord, bool) {
+	o1, o2 := Orient2D(a, b, c), Orient2D(a, b, d)
+	o3, o4 := Orient2D(c, d, a), Orient2D(c, d, b)
+	if o1*o2 >= 0 || o3*o4 >= 0 {
+		return Coord{}, false
+	}
+	return roundLineCross(a, b, c, d), true
+}
+
+// snapHotPixels 返回线段 ab 经过的热像素中心（不含 a、b），按从 a 到 b 的顺序排列。
+// hot 须按 X、Z 排序，以便按线段包围盒二分定位候选像素。
+func snapHotPixels(a, b Coord, hot []Coord) []Coord {
+	lo, _ := slices.BinarySearchFunc(hot, min(a.X, b.X)-1, func(p Coord, x int32) int {
+		return cmp.Compare(p.X, x)
+	})
+	var ret []Coord
+	for _, p := range hot[lo:] {
+		if p.X > max(a.X, b.X)+1 {
+			break
+		}
+		if p != a && p != b && isSegmentCrossPixel(a, b, p) {
+			ret = append(ret, p)
+		}
+	}
+	// 按在 ab 方向上的投影 (p - a)·(b - a) 排序
+	dx, dz := int64(b.X)-int64(a.X), int64(b.Z)-int64(a.Z)
+	slices.SortFunc(ret, func(p, q Coord) int {
+		tp := mul128(int64(p.X)-int64(a.X), dx).add(mul128(int64(p.Z)-int64(a.Z), dz))
+		tq := mul128(int64(q.X)-int64(a.X), dx).add(mul128(int64(q.Z)-int64(a.Z), dz))
+		return tp.sub(tq).sign()
+	})
+	return ret
+}
+
+// isSegmentCrossPixel 判断线段 ab 是否与以 p 为中心、边长为 1 的闭正方形有公共点。
+// 坐标放大两倍后正方形的边界均为整数，判定为精确计算：
+// 包围盒重叠，且正方形的四个角不全在线段所在直线的同一侧。
+func isSegmentCrossPixel(a, b, p Coord) bool {
+	ax, az := 2*int64(a.X), 2*int64(a.Z)
+	bx, bz := 2*int64(b.X), 2*int64(b.Z)
+	x0, x1 := 2*int64(p.X)-1, 2*int64(p.X)+1
+	z0, z1 := 2*int64(p.Z)-1, 2*int64(p.Z)+1
+	if max(ax, bx) < x0 || min(ax, bx) > x1 || max(az, bz) < z0 || min(az, bz) > z1 {
+		return false
+	}
+	dx, dz := bx-ax, bz-az
+	var pos, neg bool
+	for _, c := range [4][2]int64{{x0, z0}, {x1, z0}, {x1, z1}, {x0, z1}} {
+		switch mulSub(dx, c[1]-az, dz, c[0]-ax) {
+		case 1:
+			pos = true
+		case -1:
+			neg = true
+		default:
+			return true
+		}
+	}
+	return pos && neg
+}
+
+// windingBeside 计算紧贴子边 e 左侧（left 为 true）或右侧的点相对于有向边集合的环绕数。
+// 测试点取 P = M ± εN，其中 M 为子边中点、N 为其左法向、ε 为无穷小正数：
+// 在坐标放大两倍的整数空间中先按 M 精确比较，相等时再由 εN 一项决定符号，
+// 因此无需构造浮点偏移点，也不受子边长度与坐标大小的影响。
+func windingBeside(edges []boolEdge, e boolEdge, left bool) int {
+	mx, mz := int64(e.a.X)+int64(e.b.X), int64(e.a.Z)+int64(e.b.Z)
+	nx, nz := int64(e.a.Z)-int64(e.b.Z), int64(e.b.X)-int64(e.a.X)
+	if !left {
+		nx, nz = -nx, -nz
+	}
+	below := func(z int64) bool {
+		return z < mz || z == mz && nz >= 0
+	}
+	wn := 0
+	for _, o := range edges {
+		ax, az := 2*int64(o.a.X), 2*int64(o.a.Z)
+		bx, bz := 2*int64(o.b.X), 2*int64(o.b.Z)
+		aBelow, bBelow := below(az), below(bz)
+		if aBelow == bBelow {
+			continue
+		}
+		side := mulSub(bx-ax, mz-az, bz-az, mx-ax)
+		if side == 0 {
+			side = mulSub(bx-ax, nz, bz-az, nx)
+		}
+		if aBelow && side > 0 {
+			wn++
+		} else if bBelow && side < 0 {
+			wn--
+		}
+	}
+	return wn
+}
+
+// traceRings 将结果边串接成环：沿边行进时，在每个顶点处从来向的反方向顺时针旋转，
+// 取遇到的第一条出边（即最靠左的转向），使仅在顶点处接触的区域各自成环。
+func traceRings(edges []boolEdge) [][]Coord {
+	out := make(map[Coord][]int, len(edges))
+	for i, e := range edges {
+		out[e.a] = append(out[e.a], i)
+	}
+	next := func(i int) int {
+		e := edges[i]
+		candidates := out[e.b]
+		if len(candidates) == 0 {
+			return -1
+		}
+		back := [2]int64{int64(e.a.X) - int64(e.b.X), int64(e.a.Z) - int64(e.b.Z)}
+		dir := func(j int) [2]int64 {
+			return [2]int64{int64(edges[j].b.X) - int64(e.b.X), int64(edges[j].b.Z) - int64(e.b.Z)}
+		}
+		// 顺时针第一条即从 back 起逆时针角度最大者
+		best := candidates[0]
+		for _, j := range candidates[1:] {
+			if ccwAngleLess(back, dir(best), dir(j)) {
+				best = j
+			}
+		}
+		return best
+	}
+
+	used := make([]bool, len(edges))
+	var rings [][]Coord
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		var ring []Coord
+		closed := false
+		for i := start; ; {
+			used[i] = true
+			ring = append(ring, edges[i].a)
+			j := next(i)
+			if j == start {
+				closed = true
+				break
+			}
+			// 取整异常导致无法闭合时丢弃该环
+			if j < 0 || used[j] {
+				break
+			}
+			i = j
+		}
+		if closed {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// ccwAngleLess 判断从 ref 起逆时针旋转到 a 的角度是否小于旋转到 b 的角度，角度范围 [0, 2π)。
+func ccwAngleLess(ref, a, b [2]int64) bool {
+	half := func(v [2]int64) int {
+		c := mulSub(ref[0], v[1], ref[1], v[0])
+		if c > 0 || c == 0 && mulSub(ref[0], v[0], -ref[1], v[1]) > 0 {
+			return 0
+		}
+		return 1
+	}
+	ha, hb := half(a), half(b)
+	if ha != hb {
+		return ha < hb
+	}
+	return mulSub(a[0], b[1], a[1], b[0]) > 0
+}
+
+// assembleRings 移除环上的共线顶点，再将逆时针外环与顺时针洞组装为多边形。
+// 洞归属于包含其内侧（即结果区域一侧）的最小外环。
+func assembleRings(rings [][]Coord) []*SimplePolygon {
+	var outers, holes [][]Coord
+	for _, ring := range rings {
+		ring = removeCollinear(ring)
+		if len(ring) < 3 {
+			continue
+		}
+		if area := signedArea2(ring); area > 0 {
+			outers = append(outers, ring)
+		} else if area < 0 {
+			holes = append(holes, ring)
+		}
+	}
+
+	polygons := make([]*SimplePolygon, len(outers))
+	outerEdges := make([][]boolEdge, len(outers))
+	for i, outer := range outers {
+		polygons[i] = NewSimplePolygon(int32(i), outer)
+		for j := range outer {
+			outerEdges[i] = append(outerEdges[i], boolEdge{a: outer[j], b: outer[(j+1)%len(outer)]})
+		}
+	}
+	for _, hole := range holes {
+		e := boolEdge{a: hole[0], b: hole[1]}
+		parent := -1
+		for i := range outers {
+			if windingBeside(outerEdges[i], e, true) == 0 {
+				continue
+			}
+			if parent < 0 || signedArea2(outers[i]) < signedArea2(outers[parent]) {
+				parent = i
+			}
+		}
+		if parent >= 0 {
+			polygons[parent].Holes = append(polygons[parent].Holes, hole)
+		}
+	}
+	return polygons
+}
+
+// removeCollinear 反复移除环上与前后邻点共线的顶点（含折返的尖刺）及重复点，直至稳定。
+func removeCollinear(ring []Coord) []Coord {
+	ring = dedupeRing(ring)
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; {
+			n := len(ring)
+			prev, next := ring[(i-1+n)%n], ring[(i+1)%n]
+			if prev == next || Orient2D(prev, ring[i], next) == 0 {
+				ring = slices.Delete(ring, i, i+1)
+				changed = true
+				continue
+			}
+			i++
+		}
+		ring = dedupeRing(ring)
+	}
+	return ring
+}
+
+// ClipConvex 使用 Sutherland–Hodgman 算法求两个凸多边形的交集，顶点顺序不限。
+// 结果按逆时针排列，不相交（或交集退化为点、线段）时返回 nil。
+// 交点按四舍五入取整，结果可能与精确交集有不超过 1 个单位的偏差。
+func ClipConvex(subject, clip []Vector) []Vector {
+	if len(subject) < 3 || len(clip) < 3 {
+		return nil
+	}
+	window := vectorsToCoords(clip)
+	if signedArea2(window) < 0 {
+		slices.Reverse(window)
+	}
+	poly := vectorsToCoords(subject)
+	for i := range window {
+		c0, c1 := window[i], window[(i+1)%len(window)]
+		poly = clipPolygon(poly,
+			func(p Coord) bool { return Orient2D(c0, c1, p) >= 0 },
+			func(a, b Coord) Coord { return roundLineCross(a, b, c0, c1) },
+		)
+	}
+	return clipResult(poly)
+}
+
+// ClipConvex 求矩形与凸多边形的交集，顶点顺序不限，结果按逆时针排列，不相交时返回 nil。
+// 与 ClipConvex 函数相同，但裁剪边均为坐标轴方向，交点只需按一个坐标轴插值。
+func (rec *Rectangle) ClipConvex(vectors []Vector) []Vector {
+	if len(vectors) < 3 {
+		return nil
+	}
+	minX, minZ := rec.X, rec.Z
+	maxX, maxZ := rec.X+rec.Width, rec.Z+rec.Height
+	poly := vectorsToCoords(vectors)
+	atX := func(x int32) func(a, b Coord) Coord {
+		return func(a, b Coord) Coord {
+			return roundLerp(a, b, big.NewInt(int64(x)-int64(a.X)), big.NewInt(int64(b.X)-int64(a.X)))
+		}
+	}
+	atZ := func(z int32) func(a, b Coord) Coord {
+		return func(a, b Coord) Coord {
+			return roundLerp(a, b, big.NewInt(int64(z)-int64(a.Z)), big.NewInt(int64(b.Z)-int64(a.Z)))
+		}
+	}
+	poly = clipPolygon(poly, func(p Coord) bool { return p.X >= minX }, atX(minX))
+	poly = clipPolygon(poly, func(p Coord) bool { return p.X <= maxX }, atX(maxX))
+	poly = clipPolygon(poly, func(p Coord) bool { return p.Z >= minZ }, atZ(minZ))
+	poly = clipPolygon(poly, func(p Coord) bool { return p.Z <= maxZ }, atZ(maxZ))
+	return clipResult(poly)
+}
+
+// clipPolygon 以一条裁剪边（由 inside 描述保留的半平面）对多边形执行一轮 Sutherland–Hodgman 裁剪，
+// intersect 求跨越裁剪边的线段与裁剪边的交点。
+func clipPolygon(poly []Coord, inside func(Coord) bool, intersect func(a, b Coord) Coord) []Coord {
+	if len(poly) == 0 {
+		return nil
+	}
+	ret := make([]Coord, 0, len(poly)+1)
+	prev := poly[len(poly)-1]
+	prevIn := inside(prev)
+	for _, cur := range poly {
+		curIn := inside(cur)
+		if curIn != prevIn {
+			ret = append(ret, intersect(prev, cur))
+		}
+		if curIn {
+			ret = append(ret, cur)
+		}
+		prev, prevIn = cur, curIn
+	}
+	return ret
+}
+
+// clipResult 清理裁剪结果并统一为逆时针的位置向量，退化时返回 nil。
+func clipResult(poly []Coord) []Vector {
+	poly = removeCollinear(poly)
+	area := signedArea2(poly)
+	if len(poly) < 3 || area == 0 {
+		return nil
+	}
+	if area < 0 {
+		slices.Reverse(poly)
+	}
+	vectors := make([]Vector, len(poly))
+	for i, c := range poly {
+		vectors[i] = NewVectorByCoord(c)
+	}
+	return vectors
+}
+
+// vectorsToCoords 将位置向量列表转换为坐标列表。
+func vectorsToCoords(vectors []Vector) []Coord {
+	coords := make([]Coord, len(vectors))
+	for i, v := range vectors {
+		coords[i] = Coord(v)
+	}
+	return coords
+}
+
+// roundLineCross 求线段 ab 与直线 cd 的交点，按四舍五入取整。调用方须保证两者不平行。
+func roundLineCross(a, b, c, d Coord) Coord {
+	num, den := lineCrossParams(a, b, c, d)
+	return roundLerp(a, b, num, den)
+}
+
+// roundLerp 返回 a + (b - a) × num / den 按四舍五入（恰为 0.5 时向正无穷方向）取整后的坐标，den 不为 0。
+func roundLerp(a, b Coord, num, den *big.Int) Coord {
+	num, den = new(big.Int).Set(num), new(big.Int).Set(den)
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	twice := new(big.Int).Lsh(den, 1)
+	axis := func(from, to int32) int32 {
+		// floor((2·Δ·num + den) / (2·den)) 即四舍五入
+		q := new(big.Int).Mul(big.NewInt(int64(to)-int64(from)), num)
+		q.Lsh(q, 1).Add(q, den)
+		q.Div(q, twice)
+		return from + int32(q.Int64())
+	}
+	return Coord{X: axis(a.X, b.X), Z: axis(a.Z, b.Z)}
+}
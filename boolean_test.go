@@ -0,0 +1,244 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// squarePolygon 返回左下角为 (x, z)、边长为 w 的逆时针正方形多边形。
+func squarePolygon(x, z, w int32) *SimplePolygon {
+	return NewSimplePolygon(0, []Coord{{x, z}, {x + w, z}, {x + w, z + w}, {x, z + w}})
+}
+
+// polygonsArea 返回多边形列表的面积之和。
+func polygonsArea(ps []*SimplePolygon) float64 {
+	var area float64
+	for _, p := range ps {
+		area += p.Area()
+	}
+	return area
+}
+
+func TestBoolean(t *testing.T) {
+	a := []*SimplePolygon{squarePolygon(0, 0, 10)}
+	frame := []*SimplePolygon{
+		NewSimplePolygon(0, []Coord{{0, 0}, {30, 0}, {30, 10}, {0, 10}}),
+		NewSimplePolygon(0, []Coord{{0, 20}, {30, 20}, {30, 30}, {0, 30}}),
+	}
+	sides := []*SimplePolygon{
+		NewSimplePolygon(0, []Coord{{0, 0}, {10, 0}, {10, 30}, {0, 30}}),
+		NewSimplePolygon(0, []Coord{{20, 0}, {30, 0}, {30, 30}, {20, 30}}),
+	}
+	ring := []*SimplePolygon{NewSimplePolygon(0, []Coord{{0, 0}, {50, 0}, {50, 50}, {0, 50}}, []Coord{{10, 10}, {10, 40}, {40, 40}, {40, 10}})}
+	tests := []struct {
+		name     string
+		op       BooleanOp
+		a, b     []*SimplePolygon
+		count    int     // 结果多边形数量
+		holes    int     // 结果中洞的总数
+		area     float64 // 结果面积之和
+		in, out  Coord   // 结果内、外各一个点
+		vertices int     // 首个结果外环的顶点数，为 0 时不校验
+	}{
+		{"union overlapping", BooleanUnion, a, []*SimplePolygon{squarePolygon(5, 5, 10)}, 1, 0, 175, Coord{12, 12}, Coord{2, 12}, 8},
+		{"intersection overlapping", BooleanIntersection, a, []*SimplePolygon{squarePolygon(5, 5, 10)}, 1, 0, 25, Coord{7, 7}, Coord{2, 2}, 4},
+		{"difference overlapping", BooleanDifference, a, []*SimplePolygon{squarePolygon(5, 5, 10)}, 1, 0, 75, Coord{2, 2}, Coord{7, 7}, 6},
+		{"xor overlapping", BooleanXor, a, []*SimplePolygon{squarePolygon(5, 5, 10)}, 2, 0, 150, Coord{12, 12}, Coord{7, 7}, 0},
+		{"union sharing edge", BooleanUnion, a, []*SimplePolygon{squarePolygon(10, 0, 10)}, 1, 0, 200, Coord{10, 5}, Coord{10, 15}, 4},
+		{"union touching at corner", BooleanUnion, a, []*SimplePolygon{squarePolygon(10, 10, 10)}, 2, 0, 200, Coord{15, 15}, Coord{5, 15}, 4},
+		{"union frame encloses hole", BooleanUnion, frame, sides, 1, 1, 800, Coord{5, 15}, Coord{15, 15}, 4},
+		{"difference punches hole", BooleanDifference, []*SimplePolygon{squarePolygon(0, 0, 30)}, []*SimplePolygon{squarePolygon(10, 10, 10)}, 1, 1, 800, Coord{5, 5}, Coord{15, 15}, 4},
+		{"union island in hole", BooleanUnion, ring, []*SimplePolygon{squarePolygon(20, 20, 10)}, 2, 1, 2500 - 900 + 100, Coord{25, 25}, Coord{15, 15}, 0},
+		{"intersection disjoint", BooleanIntersection, a, []*SimplePolygon{squarePolygon(20, 0, 10)}, 0, 0, 0, Coord{}, Coord{5, 5}, 0},
+		{"intersection identical", BooleanIntersection, a, a, 1, 0, 100, Coord{5, 5}, Coord{15, 5}, 4},
+		{"difference identical", BooleanDifference, a, a, 0, 0, 0, Coord{}, Coord{5, 5}, 0},
+		{"union of overlapping group", BooleanUnion, []*SimplePolygon{squarePolygon(0, 0, 10), squarePolygon(5, 0, 10)}, nil, 1, 0, 150, Coord{12, 5}, Coord{16, 5}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Boolean(tt.op, tt.a, tt.b)
+			holes := 0
+			for _, p := range r {
+				holes += len(p.Holes)
+				if err := p.Validate(); err != nil {
+					t.Fatalf("result %v is invalid: %v", p, err)
+				}
+				if signedArea2(p.Outer) <= 0 {
+					t.Fatalf("result outer %v is not counter-clockwise", p.Outer)
+				}
+			}
+			if len(r) != tt.count || holes != tt.holes || polygonsArea(r) != tt.area {
+				t.Fatalf("Boolean() = %d polygons, %d holes, area %v, want %d, %d, %v", len(r), holes, polygonsArea(r), tt.count, tt.holes, tt.area)
+			}
+			if tt.count > 0 && !polygonsCover(r, tt.in) {
+				t.Fatalf("result does not cover %v", tt.in)
+			}
+			if polygonsCover(r, tt.out) {
+				t.Fatalf("result covers %v", tt.out)
+			}
+			if tt.vertices > 0 && len(r[0].Outer) != tt.vertices {
+				t.Fatalf("outer = %v, want %d vertices", r[0].Outer, tt.vertices)
+			}
+		})
+	}
+}
+
+// TestBooleanMatchesSampling 以随机星形多边形校验各运算的面积恒等式，
+// 并逐点校验远离输入边界的点是否在结果内与 op 的定义一致。
+func TestBooleanMatchesSampling(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	star := func() *SimplePolygon {
+		cx, cz := rng.Float64()*1000, rng.Float64()*1000
+		n := 5 + rng.IntN(10)
+		var pts []Coord
+		for i := range n {
+			a := 2 * math.Pi * float64(i) / float64(n)
+			r := 100 + rng.Float64()*400
+			pts = append(pts, Coord{int32(cx + r*math.Cos(a)), int32(cz + r*math.Sin(a))})
+		}
+		return NewSimplePolygon(0, pts)
+	}
+	// nearEdge 判断点是否距离输入多边形的边不超过 2 个单位，交点取整会影响这些点的归属
+	nearEdge := func(x, z float64, ps ...*SimplePolygon) bool {
+		for _, p := range ps {
+			for i, c := range p.Outer {
+				if floatDistanceToSegment(NewSegment(c, p.Outer[(i+1)%len(p.Outer)]), x, z) <= 2 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for range 100 {
+		p, q := star(), star()
+		if p.Validate() != nil || q.Validate() != nil {
+			continue
+		}
+		a, b := []*SimplePolygon{p}, []*SimplePolygon{q}
+		results := map[BooleanOp][]*SimplePolygon{}
+		for _, op := range []BooleanOp{BooleanUnion, BooleanIntersection, BooleanDifference, BooleanXor} {
+			results[op] = Boolean(op, a, b)
+		}
+		un, in := polygonsArea(results[BooleanUnion]), polygonsArea(results[BooleanIntersection])
+		df, xor := polygonsArea(results[BooleanDifference]), polygonsArea(results[BooleanXor])
+		// 交点取整使每个交点附近产生不超过 1 个单位的偏移，面积允许少量误差
+		if math.Abs(un+in-p.Area()-q.Area()) > 500 || math.Abs(df+in-p.Area()) > 500 || math.Abs(xor-(un-in)) > 500 {
+			t.Fatalf("area identity broken: union %v, intersection %v, difference %v, xor %v, |A| %v, |B| %v", un, in, df, xor, p.Area(), q.Area())
+		}
+		for range 200 {
+			x, z := rng.Int32N(1600)-300, rng.Int32N(1600)-300
+			if nearEdge(float64(x), float64(z), p, q) {
+				continue
+			}
+			c := Coord{x, z}
+			for op, r := range results {
+				if want := op.apply(p.IsCoordInside(c), q.IsCoordInside(c)); polygonsCover(r, c) != want {
+					t.Fatalf("Boolean(%d) covers %v = %v, want %v", op, c, !want, want)
+				}
+			}
+		}
+	}
+}
+
+func TestClipConvex(t *testing.T) {
+	square := squarePolygon(0, 0, 10).GetVectors()
+	tests := []struct {
+		name     string
+		subject  []Vector
+		area     float64
+		vertices int
+	}{
+		{"overlapping squares", squarePolygon(5, 5, 10).GetVectors(), 25, 4},
+		{"contained", squarePolygon(2, 2, 5).GetVectors(), 25, 4},
+		{"containing", squarePolygon(-5, -5, 20).GetVectors(), 100, 4},
+		{"diamond cuts corners", []Vector{{5, -2}, {12, 5}, {5, 12}, {-2, 5}}, 82, 8},
+		{"clockwise triangle", []Vector{{0, 0}, {0, 20}, {20, 0}}, 100, 4},
+		{"disjoint", squarePolygon(20, 0, 10).GetVectors(), 0, 0},
+		{"touching edge", squarePolygon(10, 0, 10).GetVectors(), 0, 0},
+		{"degenerate", []Vector{{0, 0}, {5, 5}}, 0, 0},
+	}
+	rect := NewRectangle(0, 0, 10, 10)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, got := range map[string][]Vector{
+				"ClipConvex":           ClipConvex(tt.subject, square),
+				"Rectangle.ClipConvex": rect.ClipConvex(tt.subject),
+			} {
+				if len(got) != tt.vertices {
+					t.Fatalf("%s() = %v, want %d vertices", name, got, tt.vertices)
+				}
+				if got == nil {
+					continue
+				}
+				if area := NewSimplePolygon(0, vectorsToCoords(got)).Area(); area != tt.area || signedArea2(vectorsToCoords(got)) <= 0 {
+					t.Fatalf("%s() = %v, area %v, want counter-clockwise with area %v", name, got, area, tt.area)
+				}
+			}
+		})
+	}
+}
+
+// polygonsCover 判断点是否在任一多边形内（含边界）。
+func polygonsCover(ps []*SimplePolygon, p Coord) bool {
+	for _, s := range ps {
+		if s.IsCoordInside(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestBooleanRoundedCrossings 校验交点取整后子边产生新相交时结果仍然完整：
+// 该自相交环来自星形多边形收缩 58 的原始偏移路径，(182, 15) 附近的取整交点曾使中央区域丢失。
+func TestBooleanRoundedCrossings(t *testing.T) {
+	path := []Coord{{181, 17}, {237, 29}, {182, 13}, {98, 291}, {153, 307}, {127, 256}, {-16, 329}, {10, 380}, {48, 337},
+		{-189, 129}, {-278, 53}, {-315, 96}, {-276, 138}, {-66, -60}, {-24, -316}, {-81, -325}, {-106, -273}, {41, -201},
+		{262, -123}, {281, -177}, {225, -189}}
+	r := Union([]*SimplePolygon{NewSimplePolygon(0, path)}, nil)
+	if !polygonsCover(r, Coord{-31, -179}) || !polygonsCover(r, Coord{50, 100}) {
+		t.Fatalf("Union() = %v lost the central region", r)
+	}
+	for _, p := range r {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("result %v is invalid: %v", p, err)
+		}
+	}
+}
+
+// TestBooleanNearCollinearCrossings 以大量近似共线、交点均需取整的细长条校验热像素吸附：
+// 分割一轮即可完成，结果的任意两条边不真正相交，面积恒等式在取整误差内成立。
+func TestBooleanNearCollinearCrossings(t *testing.T) {
+	var a, b []*SimplePolygon
+	for i := range int32(15) {
+		z := i * 7
+		a = append(a, NewSimplePolygon(0, []Coord{{0, z}, {100000, z + 301}, {100000, z + 304}, {0, z + 3}}))
+		b = append(b, NewSimplePolygon(0, []Coord{{0, z + 300}, {100000, z - 1}, {100000, z + 2}, {0, z + 303}}))
+	}
+	areaA, areaB := polygonsArea(Union(a, nil)), polygonsArea(Union(b, nil))
+	results := map[BooleanOp][]*SimplePolygon{}
+	for _, op := range []BooleanOp{BooleanUnion, BooleanIntersection, BooleanDifference, BooleanXor} {
+		r := Boolean(op, a, b)
+		results[op] = r
+		var edges [][2]Coord
+		for _, p := range r {
+			for _, ring := range append([][]Coord{p.Outer}, p.Holes...) {
+				for i, c := range ring {
+					edges = append(edges, [2]Coord{c, ring[(i+1)%len(ring)]})
+				}
+			}
+		}
+		for i, e1 := range edges {
+			for _, e2 := range edges[i+1:] {
+				if p, ok := properCrossCoord(e1[0], e1[1], e2[0], e2[1]); ok {
+					t.Fatalf("Boolean(%d) edges %v and %v cross at %v", op, e1, e2, p)
+				}
+			}
+		}
+	}
+	un, in := polygonsArea(results[BooleanUnion]), polygonsArea(results[BooleanIntersection])
+	df, xor := polygonsArea(results[BooleanDifference]), polygonsArea(results[BooleanXor])
+	if tol := 0.01 * (areaA + areaB); math.Abs(un+in-areaA-areaB) > tol || math.Abs(df+in-areaA) > tol || math.Abs(xor-(un-in)) > tol {
+		t.Fatalf("area identity broken: union %v, intersection %v, difference %v, xor %v, |A| %v, |B| %v", un, in, df, xor, areaA, areaB)
+	}
+}
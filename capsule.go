@@ -0,0 +1,186 @@
+package geo
+
+import "math"
+
+// Capsule 表示胶囊体：到中轴线段距离不超过 Radius 的全部点，即线段沿两侧膨胀并在两端加半圆。
+// 常用于移动中的单位、弹道与"粗线段"技能的碰撞体。
+type Capsule struct {
+	Segment
+	Radius int32
+}
+
+// NewCapsule 以中轴线段端点与半径创建胶囊体。
+func NewCapsule(a, b Coord, radius int32) Capsule {
+	return Capsule{
+		Segment: NewSegment(a, b),
+		Radius:  radius,
+	}
+}
+
+// IsCoordInside 判断点是否在胶囊体内（含边界）：点到中轴线段的距离不超过半径。
+func (c *Capsule) IsCoordInside(p Coord) bool {
+	return distanceToSegment(c.Segment, p) <= float64(c.Radius)
+}
+
+// ToRect 返回胶囊体的轴对齐包围盒：中轴线段的包围盒向四周扩展半径，超出 int32 范围时饱和。
+func (c *Capsule) ToRect() (minX, minZ, maxX, maxZ int32) {
+	minX = addInt32(min(c.A.X, c.B.X), -c.Radius)
+	minZ = addInt32(min(c.A.Z, c.B.Z), -c.Radius)
+	maxX = addInt32(max(c.A.X, c.B.X), c.Radius)
+	maxZ = addInt32(max(c.A.Z, c.B.Z), c.Radius)
+	return
+}
+
+// GetLocationToBorder 获取胶囊体包围盒与给定边界的象限重叠关系。
+func (c *Capsule) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := c.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// IntersectCircle 判断胶囊体与圆是否相交（含接触）：圆心到中轴线段的距离不超过两半径之和。
+func (c *Capsule) IntersectCircle(circle Circle) bool {
+	return distanceToSegment(c.Segment, circle.Center) <= float64(c.Radius)+float64(circle.Radius)
+}
+
+// IntersectSegment 判断胶囊体与线段是否相交（含接触）：线段到中轴线段的距离不超过半径。
+func (c *Capsule) IntersectSegment(s Segment) bool {
+	return segmentDistance(c.Segment, s) <= float64(c.Radius)
+}
+
+// IntersectConvex 判断胶囊体与凸多边形是否相交（含接触），顶点顺序不限。
+// 中轴端点在多边形内时必然相交，否则比较中轴线段到多边形各边的最短距离与半径。
+func (c *Capsule) IntersectConvex(vectors []Vector) bool {
+	if len(vectors) < 3 {
+		return false
+	}
+	if isCoordInConvexVectors(vectors, c.A) || isCoordInConvexVectors(vectors, c.B) {
+		return true
+	}
+	for i := range vectors {
+		edge := NewSegment(Coord(vectors[i]), Coord(vectors[(i+1)%len(vectors)]))
+		if segmentDistance(c.Segment, edge) <= float64(c.Radius) {
+			return true
+		}
+	}
+	return false
+}
+
+// SweepCircle 对沿 move 移动的圆做连续碰撞检测，解决高速弹道在单帧采样间"穿墙"的问题。
+// 圆心轨迹与障碍线段的碰撞等价于轨迹线段与以障碍为中轴、圆半径为半径的胶囊体求交：
+// 胶囊体两侧长边按法向距离解析求交（浮点计算，避免整数截断在掠射角下被放大），
+// 两端半圆以 Circle.GetLineCross 求交，碰撞法向量由 Segment.ClosestPoint 求得。
+//
+// 返回首次碰撞时刻 toi（move 的比例，范围 [0, 1]）、碰撞法向量（由障碍上的最近点指向圆心，
+// 长度约为 1000，与 TruncEdge 约定一致）以及是否发生碰撞。未碰撞时 toi 为 1。
+// 圆在起点即与障碍重叠时 toi 为 0，法向量为推离方向。
+func SweepCircle(c Circle, move Vector, obstacles ...Segment) (float64, Vector, bool) {
+	start := c.Center
+	end := move.ToCoord(start)
+	path := NewSegment(start, end)
+	length := move.Length()
+	radius := float64(c.Radius)
+
+	toi := math.MaxFloat64
+	var obstacle Segment
+	for _, o := range obstacles {
+		if distanceToSegment(o, start) <= radius {
+			return 0, sweepNormal(o, start, move), true
+		}
+		if length == 0 {
+			continue
+		}
+		// 长边：沿障碍法向的距离从 d0 减小到半径的时刻，且此时圆心投影落在障碍线段内
+		if t, ok := sweepSide(o, start, move, radius); ok && t < toi {
+			toi, obstacle = t, o
+		}
+		// 端点半圆：GetLineCross 返回轨迹上距起点最近的交点
+		for _, p := range [2]Coord{o.A, o.B} {
+			endCap := NewCirCle(p, c.Radius)
+			if q, ok := endCap.GetLineCross(&path); ok {
+				if t := CalDstCoordToCoord(start, q) / length; t < toi {
+					toi, obstacle = t, o
+				}
+			}
+		}
+	}
+	if toi > 1 {
+		return 1, Vector{}, false
+	}
+	contact := CalCoordByRatio(start, end, toi)
+	return toi, sweepNormal(obstacle, contact, move), true
+}
+
+// sweepSide 求移动圆心首次到达障碍线段某一侧、法向距离等于 radius 的时刻（move 的比例）。
+// 圆心须朝障碍移动，且接触时圆心在障碍方向上的投影位于线段范围内，否则由端点半圆负责。
+func sweepSide(o Segment, start Coord, move Vector, radius float64) (float64, bool) {
+	ux, uz := float64(o.B.X)-float64(o.A.X), float64(o.B.Z)-float64(o.A.Z)
+	length := math.Hypot(ux, uz)
+	if length == 0 {
+		return 0, false
+	}
+	ux, uz = ux/length, uz/length
+	// 法向取指向起点一侧，使起点的法向距离 d0 >= 0
+	nx, nz := -uz, ux
+	sx, sz := float64(start.X)-float64(o.A.X), float64(start.Z)-float64(o.A.Z)
+	d0 := sx*nx + sz*nz
+	if d0 < 0 {
+		nx, nz, d0 = -nx, -nz, -d0
+	}
+	vn := float64(move.X)*nx + float64(move.Z)*nz
+	if vn >= 0 || d0 < radius {
+		return 0, false
+	}
+	t := (d0 - radius) / -vn
+	along := (sx+t*float64(move.X))*ux + (sz+t*float64(move.Z))*uz
+	if along < 0 || along > length {
+		return 0, false
+	}
+	return t, true
+}
+
+// sweepNormal 计算碰撞法向量：由障碍线段上距圆心最近的点（ClosestPoint）指向圆心，长度约为 1000。
+// 圆心恰好落在障碍上时改用与移动方向相反的障碍法向。
+func sweepNormal(o Segment, center Coord, move Vector) Vector {
+	closest := o.ClosestPoint(center)
+	if closest != center {
+		return NewVector(closest, TruncEdge(closest, center))
+	}
+	edge := o.ToVector()
+	normal := Vector{X: -edge.Z, Z: edge.X}
+	if normal.Dot(&move) > 0 {
+		normal = Vector{X: edge.Z, Z: -edge.X}
+	}
+	if normal == (Vector{}) {
+		normal = Vector{X: -move.X, Z: -move.Z}
+	}
+	if normal == (Vector{}) {
+		return Vector{}
+	}
+	return NewVector(Coord{}, TruncEdge(Coord{}, Coord(normal)))
+}
+
+// segmentDistance 计算两条线段之间的最短距离，相交时为 0。
+// 不相交时最短距离必在某个端点到另一条线段之间取得。
+func segmentDistance(s1, s2 Segment) float64 {
+	if isSegmentCross(s1.A, s1.B, s2.A, s2.B) {
+		return 0
+	}
+	return min(
+		distanceToSegment(s1, s2.A),
+		distanceToSegment(s1, s2.B),
+		distanceToSegment(s2, s1.A),
+		distanceToSegment(s2, s1.B),
+	)
+}
+
+// distanceToSegment 计算点到线段的最短距离。
+// 与 Segment.DistanceToPoint 不同，投影点不截断为整数坐标，接触判定不受截断误差影响。
+func distanceToSegment(s Segment, p Coord) float64 {
+	dx, dz := float64(s.B.X)-float64(s.A.X), float64(s.B.Z)-float64(s.A.Z)
+	px, pz := float64(p.X)-float64(s.A.X), float64(p.Z)-float64(s.A.Z)
+	t := 0.0
+	if lengthSquared := dx*dx + dz*dz; lengthSquared > 0 {
+		t = max(0, min(1, (px*dx+pz*dz)/lengthSquared))
+	}
+	return math.Hypot(px-t*dx, pz-t*dz)
+}
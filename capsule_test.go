@@ -0,0 +1,135 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCapsule(t *testing.T) {
+	c := NewCapsule(Coord{0, 0}, Coord{100, 0}, 10)
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"point beside spine", c.IsCoordInside(Coord{50, 10}), true},
+		{"point off side", c.IsCoordInside(Coord{50, 11}), false},
+		{"point on end cap", c.IsCoordInside(Coord{-10, 0}), true},
+		{"point near end cap", c.IsCoordInside(Coord{107, 7}), true},
+		{"point off end cap", c.IsCoordInside(Coord{-8, 7}), false},
+		{"circle touching", c.IntersectCircle(NewCirCle(Coord{50, 30}, 20)), true},
+		{"circle apart", c.IntersectCircle(NewCirCle(Coord{50, 30}, 19)), false},
+		{"segment parallel apart", c.IntersectSegment(NewSegment(Coord{50, 15}, Coord{150, 15})), false},
+		{"segment touching", c.IntersectSegment(NewSegment(Coord{50, 10}, Coord{50, 40})), true},
+		{"segment crossing spine", c.IntersectSegment(NewSegment(Coord{50, -50}, Coord{50, 50})), true},
+		{"convex inside", c.IntersectConvex(squareVectors(40, -5, 10)), true},
+		{"convex containing capsule", c.IntersectConvex(squareVectors(-50, -50, 200)), true},
+		{"convex near end cap", c.IntersectConvex(squareVectors(105, 5, 20)), true},
+		{"convex beyond end cap", c.IntersectConvex(squareVectors(108, 8, 10)), false},
+		{"convex far away", c.IntersectConvex(squareVectors(200, 200, 10)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+	if minX, minZ, maxX, maxZ := c.ToRect(); minX != -10 || minZ != -10 || maxX != 110 || maxZ != 10 {
+		t.Fatalf("ToRect() = %d, %d, %d, %d, want -10, -10, 110, 10", minX, minZ, maxX, maxZ)
+	}
+	// 靠近 int32 边界时包围盒饱和而不回绕
+	edge := NewCapsule(Coord{math.MaxInt32 - 5, math.MinInt32 + 5}, Coord{0, 0}, 10)
+	if minX, minZ, maxX, maxZ := edge.ToRect(); minX != -10 || minZ != math.MinInt32 || maxX != math.MaxInt32 || maxZ != 10 {
+		t.Fatalf("ToRect() = %d, %d, %d, %d, want -10, MinInt32, MaxInt32, 10", minX, minZ, maxX, maxZ)
+	}
+}
+
+func TestSweepCircle(t *testing.T) {
+	wall := NewSegment(Coord{100, -100}, Coord{100, 100})
+	tests := []struct {
+		name      string
+		c         Circle
+		move      Vector
+		obstacles []Segment
+		hit       bool
+		toi       float64
+		normal    Vector // 为零向量时只校验法向量背离移动方向
+	}{
+		{"hit wall side", NewCirCle(Coord{0, 0}, 10), Vector{200, 0}, []Segment{wall}, true, 0.45, Vector{-1000, 0}},
+		{"move parallel", NewCirCle(Coord{0, 0}, 10), Vector{0, 200}, []Segment{wall}, false, 1, Vector{}},
+		{"stop short", NewCirCle(Coord{0, 0}, 10), Vector{50, 0}, []Segment{wall}, false, 1, Vector{}},
+		{"zero move", NewCirCle(Coord{0, 0}, 10), Vector{}, []Segment{wall}, false, 1, Vector{}},
+		{"start overlapping", NewCirCle(Coord{95, 0}, 10), Vector{200, 0}, []Segment{wall}, true, 0, Vector{-1000, 0}},
+		{"hit end cap", NewCirCle(Coord{0, 0}, 10), Vector{200, 0}, []Segment{NewSegment(Coord{100, 5}, Coord{100, 100})}, true, (100 - math.Sqrt(75)) / 200, Vector{}},
+		{"nearest of several", NewCirCle(Coord{0, 0}, 10), Vector{200, 0}, []Segment{wall, NewSegment(Coord{50, -100}, Coord{50, 100})}, true, 0.2, Vector{-1000, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toi, normal, hit := SweepCircle(tt.c, tt.move, tt.obstacles...)
+			// 端点半圆的交点取整到整数坐标，允许 1 个单位的误差
+			if hit != tt.hit || math.Abs(toi-tt.toi)*max(tt.move.Length(), 1) > 1 {
+				t.Fatalf("SweepCircle() = %v, %v, %v, want %v, %v", toi, normal, hit, tt.toi, tt.hit)
+			}
+			if !hit {
+				return
+			}
+			if tt.normal != (Vector{}) && normal != tt.normal {
+				t.Fatalf("normal = %v, want %v", normal, tt.normal)
+			}
+			if normal.Dot(&tt.move) >= 0 {
+				t.Fatalf("normal %v does not oppose move %v", normal, tt.move)
+			}
+		})
+	}
+}
+
+// TestSweepCircleMatchesSampling 以细分采样的轨迹校验随机场景下的首次碰撞时刻。
+func TestSweepCircleMatchesSampling(t *testing.T) {
+	rng := rand.New(rand.NewPCG(13, 0))
+	hits := 0
+	for range 2000 {
+		c := NewCirCle(Coord{rng.Int32N(1000), rng.Int32N(1000)}, 5+rng.Int32N(40))
+		move := Vector{rng.Int32N(2000) - 1000, rng.Int32N(2000) - 1000}
+		var obstacles []Segment
+		for range 3 {
+			obstacles = append(obstacles, NewSegment(Coord{rng.Int32N(1000), rng.Int32N(1000)}, Coord{rng.Int32N(1000), rng.Int32N(1000)}))
+		}
+		toi, normal, hit := SweepCircle(c, move, obstacles...)
+		sampled := 2.0
+		for i := 0; i <= 4000 && sampled > 1; i++ {
+			s := float64(i) / 4000
+			px, pz := float64(c.Center.X)+s*float64(move.X), float64(c.Center.Z)+s*float64(move.Z)
+			for _, o := range obstacles {
+				if floatDistanceToSegment(o, px, pz) <= float64(c.Radius) {
+					sampled = s
+				}
+			}
+		}
+		// 掠射时采样与解析解可能相差数个单位
+		if math.Abs(min(sampled, 1)-toi)*move.Length() > 3 {
+			t.Fatalf("SweepCircle(%v, %v, %v) toi = %v, sampled %v", c, move, obstacles, toi, sampled)
+		}
+		if hit {
+			hits++
+			if l := normal.Length(); l < 990 || l > 1001 {
+				t.Fatalf("normal %v length = %v, want about 1000", normal, l)
+			}
+		}
+	}
+	if hits < 100 {
+		t.Fatalf("only %d of 2000 random sweeps hit", hits)
+	}
+}
+
+// floatDistanceToSegment 计算浮点坐标点到线段的最短距离。
+func floatDistanceToSegment(s Segment, px, pz float64) float64 {
+	ax, az := float64(s.A.X), float64(s.A.Z)
+	dx, dz := float64(s.B.X)-ax, float64(s.B.Z)-az
+	k := 0.0
+	if l := dx*dx + dz*dz; l > 0 {
+		k = max(0, min(1, ((px-ax)*dx+(pz-az)*dz)/l))
+	}
+	return math.Hypot(px-ax-k*dx, pz-az-k*dz)
+}
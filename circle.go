@@ -66,6 +66,8 @@ func (c *Circle) IsIntersect(s *Segment) bool {
 //  2. 将圆心到线段起点的向量投影到线段方向，得到最近垂足参数 a；
 //  3. 利用勾股定理判断判别式（r² - e² + a²）是否大于等于 0；
 //  4. 限制 t 在 [0, fDis] 范围内，确保交点落在线段上而非延长线。
+//
+// 交点相对线段起点的偏移量按包级取整方式 Rounding 取整。
 func (c *Circle) GetLineCross(s *Segment) (Coord, bool) {
 	var coord1 *Coord
 	var coord2 *Coord
@@ -106,16 +108,16 @@ func (c *Circle) GetLineCross(s *Segment) (Coord, bool) {
 	t := a - f
 	if t > -utility.Epsilon && (t-fDis) < utility.Epsilon {
 		coord1 = &Coord{
-			X: s.A.X + int32(t*dx),
-			Z: s.A.Z + int32(t*dz),
+			X: addInt32(s.A.X, roundInt32(t*dx)),
+			Z: addInt32(s.A.Z, roundInt32(t*dz)),
 		}
 	}
 	// t2 = a + f 对应距起点较远的交点
 	t = a + f
 	if t > -utility.Epsilon && (t-fDis) < utility.Epsilon {
 		coord2 = &Coord{
-			X: s.A.X + int32(t*dx),
-			Z: s.A.Z + int32(t*dz),
+			X: addInt32(s.A.X, roundInt32(t*dx)),
+			Z: addInt32(s.A.Z, roundInt32(t*dz)),
 		}
 	}
 
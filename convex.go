@@ -1,6 +1,8 @@
 package geo
 
 import (
+	"errors"
+	"fmt"
 	"math"
 
 	"github.com/wildmap/utility/xlog"
@@ -283,17 +285,26 @@ func (c *Convex) IsCoordInside(p Coord) bool {
 }
 
 // CheckConvex 校验凸多边形的合并结果是否正确，仅用于测试阶段。
+// 校验逻辑见 Validate，失败时记录错误日志并返回 false。
+func (c *Convex) CheckConvex() bool {
+	if err := c.Validate(); err != nil {
+		xlog.Errorf("convex error %v", err)
+		return false
+	}
+	return true
+}
+
+// Validate 校验凸多边形的合并结果是否正确，失败时返回描述原因的错误。
 // 双重校验：
 //  1. 顶点列表是否满足凸性（IsConvex）；
 //  2. 合并三角形覆盖的顶点集合是否与凸多边形顶点集合完全一致。
-func (c *Convex) CheckConvex() bool {
+func (c *Convex) Validate() error {
 	vers := make(map[int32]bool)
 	for _, ver := range c.Vertices {
 		vers[ver.Index] = false
 	}
 	if !IsConvex(c.Vertices) {
-		xlog.Errorf("convex error not a convex")
-		return false
+		return errors.New("geo: convex vertices are not convex")
 	}
 	count1 := len(vers)
 	// 标记合并三角形中出现的所有顶点
@@ -306,17 +317,15 @@ func (c *Convex) CheckConvex() bool {
 	// 检查是否有顶点未被任何合并三角形覆盖
 	for key, value := range vers {
 		if !value {
-			xlog.Errorf("convex error has not exist value %d", key)
-			return false
+			return fmt.Errorf("geo: convex vertex %d is not covered by merged triangles", key)
 		}
 	}
 
 	// 合并三角形覆盖的顶点数必须等于凸多边形顶点数
 	if count1 != count2 {
-		xlog.Errorf("convex error has not merged\nvertice convex vertices count: %d merge vertices count: %d\n", count1, count2)
-		return false
+		return fmt.Errorf("geo: convex has %d vertices but merged triangles cover %d", count1, count2)
 	}
-	return true
+	return nil
 }
 
 // CounterClockWiseSort 将顶点列表原地调整为逆时针排列。
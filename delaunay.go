@@ -0,0 +1,393 @@
+package geo
+
+import (
+	"cmp"
+	"slices"
+)
+
+// 本文件实现 NavMeshBuilder 使用的约束 Delaunay 三角剖分，流程为：
+//  1. triangulatePoints 以扫描法构造覆盖全部顶点凸包的初始三角剖分；
+//  2. flipToDelaunay 以 Lawson 翻转将其优化为 Delaunay 三角剖分；
+//  3. insertConstraint 依次翻转与环边相交的边，使每条环边都成为三角网的边（Sloan 方法）；
+//  4. 再次以环边为约束执行 Lawson 翻转，得到约束 Delaunay 三角剖分；
+//  5. interior 按穿过约束边次数的奇偶性去掉外边界以外与洞内的三角形。
+
+// triangulation 表示剖分过程中可修改的三角网，三角形以顶点序号描述且均为逆时针。
+// edgeTris 记录每条边（GenEdgeKey）所属的三角形下标；翻转时两个三角形原位替换，下标保持不变。
+type triangulation struct {
+	coords   []Coord
+	tris     [][3]int32
+	edgeTris map[int64][]int
+}
+
+// newTriangulation 以坐标表和逆时针三角形创建三角网。
+func newTriangulation(coords []Coord, tris [][3]int32) *triangulation {
+	t := &triangulation{
+		coords:   coords,
+		tris:     tris,
+		edgeTris: make(map[int64][]int, len(tris)*3/2+1),
+	}
+	for i := range tris {
+		t.link(i)
+	}
+	return t
+}
+
+// link 将第 ti 个三角形登记到其三条边上。
+func (t *triangulation) link(ti int) {
+	tri := t.tris[ti]
+	for k := range 3 {
+		key := GenEdgeKey(tri[k], tri[(k+1)%3])
+		t.edgeTris[key] = append(t.edgeTris[key], ti)
+	}
+}
+
+// unlink 从三条边上移除第 ti 个三角形的登记。
+func (t *triangulation) unlink(ti int) {
+	tri := t.tris[ti]
+	for k := range 3 {
+		key := GenEdgeKey(tri[k], tri[(k+1)%3])
+		t.edgeTris[key] = slices.DeleteFunc(t.edgeTris[key], func(i int) bool { return i == ti })
+	}
+}
+
+// quad 返回共享边 key 的两个三角形及其四个顶点：t1 中共享边为 u→v、对角顶点为 w，t2 的对角顶点为 x。
+// 边不被两个三角形共享时返回 false。
+func (t *triangulation) quad(key int64) (t1, t2 int, u, v, w, x int32, ok bool) {
+	ts := t.edgeTris[key]
+	if len(ts) != 2 {
+		return 0, 0, 0, 0, 0, 0, false
+	}
+	t1, t2 = ts[0], ts[1]
+	u, v, w = rotateToEdge(t.tris[t1], key)
+	for _, vi := range t.tris[t2] {
+		if vi != u && vi != v {
+			x = vi
+		}
+	}
+	return t1, t2, u, v, w, x, true
+}
+
+// flipQuad 将 quad 返回的四边形 u-x-v-w 的对角线 u-v 翻转为 w-x。
+// 翻转后的两个三角形 (u,x,w) 与 (x,v,w) 必须均为严格逆时针（即四边形严格凸），否则不翻转并返回 false。
+func (t *triangulation) flipQuad(t1, t2 int, u, v, w, x int32) bool {
+	c := t.coords
+	if cross(c[x], c[w], c[u]) <= 0 || cross(c[v], c[w], c[x]) <= 0 {
+		return false
+	}
+	t.unlink(t1)
+	t.unlink(t2)
+	t.tris[t1] = [3]int32{u, x, w}
+	t.tris[t2] = [3]int32{x, v, w}
+	t.link(t1)
+	t.link(t2)
+	return true
+}
+
+// triangulatePoints 以扫描法构造点集的三角剖分，结果覆盖全部顶点的凸包。
+// 顶点按 (X, Z) 字典序依次加入：新顶点大于所有已加入的顶点，必然严格位于当前凸包之外，
+// 与凸包上对其可见的边（连续的一段）连接成三角形，并替换被遮挡的凸包顶点。
+// 所有顶点共线时无法剖分，返回 nil。
+func triangulatePoints(coords []Coord) [][3]int32 {
+	order := make([]int32, len(coords))
+	for i := range order {
+		order[i] = int32(i)
+	}
+	slices.SortFunc(order, func(a, b int32) int {
+		return cmp.Or(cmp.Compare(coords[a].X, coords[b].X), cmp.Compare(coords[a].Z, coords[b].Z))
+	})
+	orient := func(a, b, c int32) int64 {
+		return cross(coords[b], coords[c], coords[a])
+	}
+	if len(order) < 3 {
+		return nil
+	}
+	// 前 k 个顶点共线（按字典序即沿直线排列），与第 k 个顶点构成首批三角形
+	k := 2
+	for k < len(order) && orient(order[0], order[1], order[k]) == 0 {
+		k++
+	}
+	if k == len(order) {
+		return nil
+	}
+	p := order[k]
+	left := orient(order[0], order[1], p) > 0
+	tris := make([][3]int32, 0, 2*len(coords))
+	hull := make([]int32, 0, len(coords))
+	for i := range k - 1 {
+		if left {
+			tris = append(tris, [3]int32{order[i], order[i+1], p})
+		} else {
+			tris = append(tris, [3]int32{order[i+1], order[i], p})
+		}
+	}
+	if left {
+		hull = append(hull, order[:k]...)
+		hull = append(hull, p)
+	} else {
+		hull = append(hull, p)
+		for i := k - 1; i >= 0; i-- {
+			hull = append(hull, order[i])
+		}
+	}
+
+	for _, p := range order[k+1:] {
+		n := len(hull)
+		// 凸包逆时针排列，新顶点在凸包边 hull[i]→hull[i+1] 右侧时该边可见
+		visible := func(i int) bool {
+			return orient(hull[i%n], hull[(i+1)%n], p) < 0
+		}
+		s := 0
+		for s < n && (!visible(s) || visible(s+n-1)) {
+			s++
+		}
+		if s == n {
+			return nil
+		}
+		e := s
+		for visible(e) {
+			tris = append(tris, [3]int32{hull[(e+1)%n], hull[e%n], p})
+			e++
+		}
+		// hull[s+1..e-1] 被新顶点遮挡，新凸包为 hull[e..s]（循环）后接新顶点
+		next := make([]int32, 0, n+1)
+		for i := e; ; i++ {
+			next = append(next, hull[i%n])
+			if i%n == s {
+				break
+			}
+		}
+		hull = append(next, p)
+	}
+	return tris
+}
+
+// flipToDelaunay 使用 Lawson 边翻转算法将三角网优化为（约束）Delaunay 三角剖分。
+// 对每条非约束的内部边，若对侧顶点落在三角形外接圆内且四边形严格凸，则翻转对角线，
+// 并将四边形外围四条边重新入栈检查，直至所有非约束边均满足局部 Delaunay 条件。
+// constraints 中的边（外边界与洞的环边）永远不会被翻转，为 nil 时得到无约束的 Delaunay 三角剖分。
+func (t *triangulation) flipToDelaunay(constraints map[int64]bool) {
+	stack := make([]int64, 0, len(t.edgeTris))
+	for key := range t.edgeTris {
+		if !constraints[key] {
+			stack = append(stack, key)
+		}
+	}
+	// 按键排序保证相同输入得到相同的剖分结果
+	slices.Sort(stack)
+
+	c := t.coords
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		t1, t2, u, v, w, x, ok := t.quad(key)
+		if !ok || InCircle(c[u], c[v], c[w], c[x]) <= 0 {
+			continue
+		}
+		if !t.flipQuad(t1, t2, u, v, w, x) {
+			continue
+		}
+		for _, e := range [][2]int32{{u, x}, {x, v}, {v, w}, {w, u}} {
+			k := GenEdgeKey(e[0], e[1])
+			if !constraints[k] {
+				stack = append(stack, k)
+			}
+		}
+	}
+}
+
+// insertConstraint 使线段 a-b 成为三角网的边，并登记到 constraints 中，返回实际插入的有向约束段。
+// 采用 Sloan 方法：收集与线段 ab 严格相交的边，依次翻转其所在的严格凸四边形，
+// 翻转后仍与 ab 相交的新边重新入队，直至不再有相交边。
+// 线段内部经过其它顶点时在该顶点处拆分为两段分别插入。
+// 与已插入的约束边相交（环自相交或相互交叠）时返回 ErrTriangulate。
+func (t *triangulation) insertConstraint(a, b int32, constraints map[int64]bool) ([][2]int32, error) {
+	key := GenEdgeKey(a, b)
+	if len(t.edgeTris[key]) > 0 {
+		// 三角形均非退化，已存在的边内部不可能经过其它顶点
+		constraints[key] = true
+		return [][2]int32{{a, b}}, nil
+	}
+
+	if mid := t.vertexOnSegment(a, b); mid >= 0 {
+		first, err := t.insertConstraint(a, mid, constraints)
+		if err != nil {
+			return nil, err
+		}
+		second, err := t.insertConstraint(mid, b, constraints)
+		if err != nil {
+			return nil, err
+		}
+		return append(first, second...), nil
+	}
+
+	var queue [][2]int32
+	seen := make(map[int64]bool)
+	for _, tri := range t.tris {
+		for k := range 3 {
+			e := [2]int32{tri[k], tri[(k+1)%3]}
+			ek := GenEdgeKey(e[0], e[1])
+			if seen[ek] || !t.crossesSegment(e, a, b) {
+				continue
+			}
+			if constraints[ek] {
+				return nil, ErrTriangulate
+			}
+			seen[ek] = true
+			queue = append(queue, e)
+		}
+	}
+
+	// stall 记录连续无法翻转的次数，超过队列长度说明没有可翻转的四边形
+	stall := 0
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		t1, t2, u, v, w, x, ok := t.quad(GenEdgeKey(e[0], e[1]))
+		if !ok {
+			return nil, ErrTriangulate
+		}
+		if !t.flipQuad(t1, t2, u, v, w, x) {
+			queue = append(queue, e)
+			if stall++; stall > len(queue) {
+				return nil, ErrTriangulate
+			}
+			continue
+		}
+		stall = 0
+		if ne := [2]int32{w, x}; t.crossesSegment(ne, a, b) {
+			queue = append(queue, ne)
+		}
+	}
+	if len(t.edgeTris[key]) == 0 {
+		return nil, ErrTriangulate
+	}
+	constraints[key] = true
+	return [][2]int32{{a, b}}, nil
+}
+
+// vertexOnSegment 返回落在线段 ab 内部（不含端点）且距 a 最近的顶点序号，不存在时返回 -1。
+func (t *triangulation) vertexOnSegment(a, b int32) int32 {
+	c := t.coords
+	ax, az := int64(c[a].X), int64(c[a].Z)
+	dx, dz := int64(c[b].X)-ax, int64(c[b].Z)-az
+	best, bestDot := int32(-1), int64(0)
+	for i, p := range c {
+		vi := int32(i)
+		if vi == a || vi == b || cross(c[b], p, c[a]) != 0 {
+			continue
+		}
+		// 共线时以 (p-a)·(b-a) 判断 p 是否位于两端点之间
+		dot := (int64(p.X)-ax)*dx + (int64(p.Z)-az)*dz
+		if dot <= 0 || dot >= dx*dx+dz*dz {
+			continue
+		}
+		if best < 0 || dot < bestDot {
+			best, bestDot = vi, dot
+		}
+	}
+	return best
+}
+
+// crossesSegment 判断边 e 与线段 ab 是否严格相交（交于双方内部的一点），共享端点不算相交。
+func (t *triangulation) crossesSegment(e [2]int32, a, b int32) bool {
+	if e[0] == a || e[0] == b || e[1] == a || e[1] == b {
+		return false
+	}
+	c := t.coords
+	opposite := func(d1, d2 int64) bool {
+		return (d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)
+	}
+	return opposite(cross(c[b], c[e[0]], c[a]), cross(c[b], c[e[1]], c[a])) &&
+		opposite(cross(c[e[1]], c[a], c[e[0]]), cross(c[e[1]], c[b], c[e[0]]))
+}
+
+// interior 返回位于可通行区域内的三角形。
+// 从凸包外侧出发，按到达三角形所需穿过的约束边数的奇偶性划分内外：奇数在外边界内且不在洞内。
+// 随后校验每条有向约束段（外环逆时针、洞顺时针）的左侧为可通行区域、右侧不是，
+// 不满足时说明洞位于外边界之外或位于其它洞内，返回 ErrHoleOutside。
+func (t *triangulation) interior(constraints map[int64]bool, segments [][2]int32) ([][3]int32, error) {
+	depth := make([]int, len(t.tris))
+	for i := range depth {
+		depth[i] = -1
+	}
+	// stack 为当前层可直接到达的三角形，next 为穿过约束边后到达的下一层三角形
+	var stack, next []int
+	for ti, tri := range t.tris {
+		for k := range 3 {
+			key := GenEdgeKey(tri[k], tri[(k+1)%3])
+			if len(t.edgeTris[key]) != 1 {
+				continue
+			}
+			if constraints[key] {
+				next = append(next, ti)
+			} else if depth[ti] < 0 {
+				depth[ti] = 0
+				stack = append(stack, ti)
+			}
+		}
+	}
+	for d := 0; len(stack) > 0 || len(next) > 0; d++ {
+		for len(stack) > 0 {
+			ti := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			tri := t.tris[ti]
+			for k := range 3 {
+				key := GenEdgeKey(tri[k], tri[(k+1)%3])
+				for _, nb := range t.edgeTris[key] {
+					if nb == ti || depth[nb] >= 0 {
+						continue
+					}
+					if constraints[key] {
+						next = append(next, nb)
+					} else {
+						depth[nb] = d
+						stack = append(stack, nb)
+					}
+				}
+			}
+		}
+		for _, ti := range next {
+			if depth[ti] < 0 {
+				depth[ti] = d + 1
+				stack = append(stack, ti)
+			}
+		}
+		next = next[:0]
+	}
+
+	for _, s := range segments {
+		key := GenEdgeKey(s[0], s[1])
+		hasLeft := false
+		for _, ti := range t.edgeTris[key] {
+			// 三角形逆时针，其中与约束段同向的一侧位于约束段左侧
+			_, v, _ := rotateToEdge(t.tris[ti], key)
+			left := v == s[1]
+			hasLeft = hasLeft || left
+			if left != (depth[ti]%2 == 1) {
+				return nil, ErrHoleOutside
+			}
+		}
+		if !hasLeft {
+			return nil, ErrHoleOutside
+		}
+	}
+
+	tris := make([][3]int32, 0, len(t.tris))
+	for ti, tri := range t.tris {
+		if depth[ti]%2 == 1 {
+			tris = append(tris, tri)
+		}
+	}
+	return tris, nil
+}
+
+// rotateToEdge 旋转三角形顶点顺序，使由 key 描述的边成为前两个顶点，返回 (u, v, w)。
+func rotateToEdge(t [3]int32, key int64) (u, v, w int32) {
+	for k := range 3 {
+		if GenEdgeKey(t[k], t[(k+1)%3]) == key {
+			return t[k], t[(k+1)%3], t[(k+2)%3]
+		}
+	}
+	return t[0], t[1], t[2]
+}
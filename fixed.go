@@ -0,0 +1,382 @@
+package geo
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// 本文件提供基于定点数的确定性三角函数，供帧同步（lockstep）与战斗回放使用。
+// math.Sin、math.Cos 等超越函数在不同平台上的实现不同，编译器还可能将浮点乘加融合为 FMA 指令，
+// 导致同一输入在不同 CPU 上得到不同的截断结果。定点版本全程只使用整数运算，
+// 查找表由 math/big 以软件浮点生成，因此在任何平台上都输出逐位相同的坐标。
+
+// Angle 表示定点角度，一整圈为 AngleFull（65536）个单位。旋转方向与 Vector.Rotate 一致：
+// 左手坐标系下正角度为顺时针方向，即由 X 轴正方向转向 Z 轴正方向。
+// 角度可以超出一整圈，参与计算时自动按整圈取模。
+type Angle int32
+
+const (
+	AngleFull    Angle = 1 << 16       // 一整圈（2π）
+	AngleHalf    Angle = AngleFull / 2 // 半圈（π）
+	AngleQuarter Angle = AngleFull / 4 // 直角（π/2）
+)
+
+// FixedOne 为 Angle.Sin、Angle.Cos 返回的 Q16 定点数中 1.0 对应的整数值。
+const FixedOne = 1 << 16
+
+// AngleFromRadians 将弧度转换为定点角度（四舍五入）。
+// 转换本身使用浮点运算，只应在加载配置等非帧同步逻辑中调用一次，之后全程使用 Angle。
+func AngleFromRadians(rad float64) Angle {
+	return Angle(math.Round(rad * float64(AngleFull) / (2 * math.Pi)))
+}
+
+// AngleFromDegrees 将整数角度（度）转换为定点角度，四舍五入（恰为 0.5 时远离 0），全程为整数运算。
+func AngleFromDegrees(deg int32) Angle {
+	return Angle(divRound(int64(deg)*int64(AngleFull), 360))
+}
+
+// Radians 将定点角度转换为弧度，用于日志与调试显示。
+func (a Angle) Radians() float64 {
+	return float64(a) * 2 * math.Pi / float64(AngleFull)
+}
+
+// Normalize 将角度规范化到 [0, AngleFull) 范围内。
+func (a Angle) Normalize() Angle {
+	return a & (AngleFull - 1)
+}
+
+// Sin 返回角度正弦值的 Q16 定点表示，范围 [-FixedOne, FixedOne]。
+func (a Angle) Sin() int32 {
+	table := sinTable()
+	a = a.Normalize()
+	r := a % AngleQuarter
+	switch a / AngleQuarter {
+	case 0:
+		return table[r]
+	case 1:
+		return table[AngleQuarter-r]
+	case 2:
+		return -table[r]
+	default:
+		return -table[AngleQuarter-r]
+	}
+}
+
+// Cos 返回角度余弦值的 Q16 定点表示，范围 [-FixedOne, FixedOne]。
+func (a Angle) Cos() int32 {
+	return (a + AngleQuarter).Sin()
+}
+
+var (
+	sinOnce   sync.Once
+	sinValues [AngleQuarter + 1]int32
+)
+
+// sinTable 返回第一象限 [0, π/2] 的 Q16 正弦查找表，首次调用时生成。
+// 以 128 位精度的 big.Float 计算单位步长的正弦与余弦（泰勒级数），再按旋转递推逐项生成，
+// 软件浮点的结果与平台无关；递推累积误差远小于 Q16 的分辨率。
+func sinTable() *[AngleQuarter + 1]int32 {
+	sinOnce.Do(func() {
+		const prec = 128
+		pi, _, _ := big.ParseFloat("3.14159265358979323846264338327950288419716939937510582097494459", 10, prec, big.ToNearestEven)
+		step := new(big.Float).SetPrec(prec).Quo(pi, big.NewFloat(float64(AngleHalf)))
+		sinStep, cosStep := bigSinCos(step, prec)
+
+		s := new(big.Float).SetPrec(prec)
+		c := new(big.Float).SetPrec(prec).SetInt64(1)
+		scale := new(big.Float).SetPrec(prec).SetInt64(FixedOne)
+		half := big.NewFloat(0.5)
+		for i := range sinValues {
+			v := new(big.Float).SetPrec(prec).Mul(s, scale)
+			v.Add(v, half)
+			n, _ := v.Int64()
+			sinValues[i] = int32(n)
+			// (c, s) ← (c·cosδ - s·sinδ, s·cosδ + c·sinδ)
+			c1 := new(big.Float).SetPrec(prec).Mul(c, cosStep)
+			c1.Sub(c1, new(big.Float).SetPrec(prec).Mul(s, sinStep))
+			s1 := new(big.Float).SetPrec(prec).Mul(s, cosStep)
+			s1.Add(s1, new(big.Float).SetPrec(prec).Mul(c, sinStep))
+			c, s = c1, s1
+		}
+	})
+	return &sinValues
+}
+
+// bigSinCos 以泰勒级数计算小角度 x 的正弦与余弦。
+func bigSinCos(x *big.Float, prec uint) (*big.Float, *big.Float) {
+	sin := new(big.Float).SetPrec(prec)
+	cos := new(big.Float).SetPrec(prec)
+	term := new(big.Float).SetPrec(prec).SetInt64(1)
+	// term 依次为 x^k / k!，k 为偶数时累加到余弦，奇数时累加到正弦，符号按 k/2 交替
+	for k := int64(0); k < 30; k++ {
+		dst, sign := cos, int64(k/2%2)
+		if k%2 == 1 {
+			dst = sin
+		}
+		if sign == 0 {
+			dst.Add(dst, term)
+		} else {
+			dst.Sub(dst, term)
+		}
+		term = new(big.Float).SetPrec(prec).Mul(term, x)
+		term.Quo(term, new(big.Float).SetInt64(k+1))
+	}
+	return sin, cos
+}
+
+// Atan2Fixed 返回向量 (x, z) 的方向角，范围 (-AngleHalf, AngleHalf]，与 math.Atan2(z, x) 的约定一致。
+// 先将向量按直角旋转到第一象限，再在正弦查找表上二分查找最接近的角度，全程为整数运算。
+// 零向量返回 0；参数不可为 math.MinInt64。
+func Atan2Fixed(z, x int64) Angle {
+	if x == 0 && z == 0 {
+		return 0
+	}
+	// 顺时针旋转直角直到落入第一象限（x > 0, z >= 0）
+	quadrant := Angle(0)
+	for !(x > 0 && z >= 0) {
+		x, z = z, -x
+		quadrant++
+	}
+	table := sinTable()
+	// side(t) 为方向 t 与向量的叉积 cos(t)·z - sin(t)·x，非负表示向量不早于方向 t
+	side := func(t Angle) int128 {
+		return mul128(int64(table[AngleQuarter-t]), z).sub(mul128(int64(table[t]), x))
+	}
+	lo, hi := Angle(0), AngleQuarter
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if side(mid).sign() >= 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	// 向量位于 lo 与 lo+1 之间，取叉积绝对值较小（夹角较小）的一侧
+	t := lo
+	if d := side(lo).add(side(lo + 1)); d.sign() > 0 {
+		t = lo + 1
+	}
+	a := (t + quadrant*AngleQuarter).Normalize()
+	if a > AngleHalf {
+		a -= AngleFull
+	}
+	return a
+}
+
+// RotateFixed 将向量按定点角度旋转，是 Rotate 的定点版本，旋转方向与 Rotate 一致
+// （左手坐标系下正角度为顺时针方向），结果分量四舍五入。
+func (v *Vector) RotateFixed(angle Angle) Vector {
+	cos, sin := int64(angle.Cos()), int64(angle.Sin())
+	x, z := int64(v.X), int64(v.Z)
+	return Vector{
+		X: int32(divRound(x*cos-z*sin, FixedOne)),
+		Z: int32(divRound(x*sin+z*cos, FixedOne)),
+	}
+}
+
+// GetAngleFixed 返回两向量之间的夹角，范围 [0, AngleHalf]，是 GetAngle 的定点版本。
+// 任意一个向量为零向量时返回 0。
+func (v *Vector) GetAngleFixed(vec *Vector) Angle {
+	if (v.X == 0 && v.Z == 0) || (vec.X == 0 && vec.Z == 0) {
+		return 0
+	}
+	dot := int64(v.X)*int64(vec.X) + int64(v.Z)*int64(vec.Z)
+	c := v.Cross(vec)
+	return Atan2Fixed(max(c, -c), dot)
+}
+
+// CalCoordDstFixed 计算目标点到本向量所在直线的垂直距离，是 CalCoordDst 的定点版本，结果四舍五入为整数。
+// 距离为 |v × (target - start)| / |v|，以 math/big 精确计算，不经过三角函数；本向量为零向量时返回 0。
+func (v *Vector) CalCoordDstFixed(start, target Coord) int64 {
+	if v.X == 0 && v.Z == 0 {
+		return 0
+	}
+	dx, dz := int64(target.X)-int64(start.X), int64(target.Z)-int64(start.Z)
+	c := mul128(int64(v.X), dz).sub(mul128(int64(v.Z), dx)).big()
+	l2 := big.NewInt(int64(v.X)*int64(v.X) + int64(v.Z)*int64(v.Z))
+	// round(√x) = ⌊(⌊√(4x)⌋ + 1) / 2⌋，其中 x = c² / |v|²，而 ⌊√(4x)⌋ = isqrt(⌊4c² / |v|²⌋)
+	q := new(big.Int).Mul(c, c)
+	q.Lsh(q, 2).Quo(q, l2).Sqrt(q)
+	return q.Add(q, big.NewInt(1)).Rsh(q, 1).Int64()
+}
+
+// RotateFixed 是 OrientedRect.Rotate 的定点版本，按定点角度旋转朝向，旋转方向与 Vector.RotateFixed 一致，结果在任何平台上逐位相同。
+func (o *OrientedRect) RotateFixed(angle Angle) OrientedRect {
+	facing := scaleVectorFixed(o.Facing, 1000)
+	ret := *o
+	ret.Facing = facing.RotateFixed(angle)
+	return ret
+}
+
+// GetCutOffCoordAngleFixed 计算从圆外一点到圆的切线角度，是 GetCutOffCoordAngle 的定点版本。
+// cos(∠ECP) = radius/dst 等价于 ∠ECP = atan2(√(dst² - radius²), radius)，点在圆内时返回 0。
+func GetCutOffCoordAngleFixed(endCoord, centerCoord Coord, radius int32) Angle {
+	dx, dz := int64(endCoord.X)-int64(centerCoord.X), int64(endCoord.Z)-int64(centerCoord.Z)
+	dst2 := uint64(dx*dx) + uint64(dz*dz)
+	r2 := uint64(int64(radius) * int64(radius))
+	if dst2 < r2 {
+		return 0
+	}
+	return Atan2Fixed(int64(isqrt(dst2-r2)), int64(radius))
+}
+
+// GetCoordsAroundFixed 是 GetCoordsAround 的定点版本，计算绕圆心到达外部目标点切线方向的弧线路径点集。
+func GetCoordsAroundFixed(startCoord, endCoord, centerCoord Coord) []Coord {
+	centerVector := NewVector(centerCoord, startCoord)
+	endVector := NewVector(centerCoord, endCoord)
+
+	angle := centerVector.GetAngleFixed(&endVector)
+	radius := int32(isqrt(uint64(int64(centerVector.X)*int64(centerVector.X) + int64(centerVector.Z)*int64(centerVector.Z))))
+	angle -= GetCutOffCoordAngleFixed(endCoord, centerCoord, radius)
+	// 叉积 > 0 表示 endVector 在 centerVector 左侧，需逆时针旋转（取负角）
+	if centerVector.Cross(&endVector) > 0 {
+		angle = -angle
+	}
+	return GetArcCoordsFixed(startCoord, centerCoord, angle)
+}
+
+// GetCoordsAround2Fixed 是 GetCoordsAround2 的定点版本，在圆周上以均匀角度间隔采样 n 个点（含起点）。
+func GetCoordsAround2Fixed(circleCoord, centerCoord Coord, n int) []Coord {
+	return getCoordsAroundFixed(circleCoord, centerCoord, n, AngleFull)
+}
+
+// GetArcCoordsFixed 是 GetArcCoords 的定点版本：angle > 0 为顺时针方向，
+// 采样密度同样约为每弧度 10 个点（以 2π ≈ 710/113 换算），最少 2 个点。
+func GetArcCoordsFixed(startCoord, centerCoord Coord, angle Angle) []Coord {
+	return getCoordsAroundFixed(startCoord, centerCoord, arcSamples(angle), -angle)
+}
+
+// GetSpiralCoordsFixed 是 GetSpiralCoords 的定点版本，生成阿基米德螺旋线路径点集，
+// 旋转方向与 GetSpiralCoords 一致；半径由整数平方根求得，缩放结果四舍五入。
+func GetSpiralCoordsFixed(startCoord, centerCoord Coord, angle Angle, delta int32) []Coord {
+	n := arcSteps(angle)
+	vec := NewVector(centerCoord, startCoord)
+	radius := int64(isqrt(uint64(int64(vec.X)*int64(vec.X) + int64(vec.Z)*int64(vec.Z))))
+	if radius == 0 {
+		// 起点与圆心重合时无法确定方向，直接返回单点
+		return []Coord{startCoord}
+	}
+	scale := func(v Vector, num, den int64) Vector {
+		return Vector{X: saturateInt32(mulDivRound(int64(v.X), num, den)), Z: saturateInt32(mulDivRound(int64(v.Z), num, den))}
+	}
+	if n < 2 {
+		// 旋转弧度极小时退化为直线延伸，沿圆心→起点方向缩放 delta
+		return []Coord{startCoord, scale(vec, radius+int64(delta), radius).ToCoord(centerCoord)}
+	}
+
+	ret := make([]Coord, n)
+	ret[0] = startCoord
+	for i := int64(1); i < n; i++ {
+		v := vec.RotateFixed(Angle(int64(angle) * i / n))
+		// 第 i 个点的半径为 radius + delta·i/n，统一通分为 (radius·n + delta·i) / (radius·n)
+		v = scale(v, radius*n+int64(delta)*i, radius*n)
+		ret[i] = v.ToCoord(centerCoord)
+	}
+	return ret
+}
+
+// getCoordsAroundFixed 是 getCoordsAround 的定点版本：从起点出发按 angle 均匀采样 n 个点（含起点）。
+// 第 i 个点直接按总角度的 i/(n-1) 旋转起点向量，不累积误差。
+func getCoordsAroundFixed(startCoord, centerCoord Coord, n int, angle Angle) []Coord {
+	if n < 2 {
+		return nil
+	}
+	ret := make([]Coord, n)
+	ret[0] = startCoord
+	vec := NewVector(centerCoord, startCoord)
+	for i := 1; i < n; i++ {
+		v := vec.RotateFixed(Angle(int64(angle) * int64(i) / int64(n-1)))
+		ret[i] = v.ToCoord(centerCoord)
+	}
+	return ret
+}
+
+// arcSamples 返回圆弧的采样点数：每弧度约 10 个点，最少 2 个。
+func arcSamples(angle Angle) int {
+	return int(max(arcSteps(angle), 2))
+}
+
+// arcSteps 返回 |angle| 弧度的 10 倍向下取整，与浮点版本的 int(|angle|·10) 对应。
+// 弧度 = |angle|·2π/AngleFull，以 2π ≈ 710/113 做整数换算。
+func arcSteps(angle Angle) int64 {
+	a := max(int64(angle), -int64(angle))
+	return a * 10 * 710 / (113 * int64(AngleFull))
+}
+
+// scaleVectorFixed 返回与 v 同向、长度约为 length 的向量，长度由整数平方根求得，分量四舍五入；
+// v 为零向量时取 X 轴正方向。全程为整数运算，是 Vector.Trunc 缩放的定点替代。
+// 短向量先放大 2^k 倍再开方，使整数平方根的相对误差不超过约 2^-15。
+func scaleVectorFixed(v Vector, length int64) Vector {
+	x, z := int64(v.X), int64(v.Z)
+	l2 := uint64(x*x + z*z)
+	if l2 == 0 {
+		return Vector{X: int32(length)}
+	}
+	k := 0
+	for l2 < 1<<30 {
+		l2 <<= 2
+		k++
+	}
+	l := int64(isqrt(l2))
+	return Vector{X: saturateInt32(mulDivRound(x<<k, length, l)), Z: saturateInt32(mulDivRound(z<<k, length, l))}
+}
+
+// divRound 返回 a / b 四舍五入（恰为 0.5 时远离 0）的结果，b > 0。
+func divRound(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
+}
+
+// mulDivRound 返回 a·b / c 四舍五入（恰为 0.5 时远离 0）的结果，c > 0。
+// 乘积以 128 位整数计算，结果超出 int64 范围时饱和到 math.MaxInt64 / math.MinInt64。
+func mulDivRound(a, b, c int64) int64 {
+	neg := (a < 0) != (b < 0)
+	// 以无符号数取绝对值，math.MinInt64 取反后恰为 2^63，不会溢出
+	ua, ub := uint64(a), uint64(b)
+	if a < 0 {
+		ua = -ua
+	}
+	if b < 0 {
+		ub = -ub
+	}
+	hi, lo := bits.Mul64(ua, ub)
+	// 加上 c/2 后整除，即四舍五入
+	lo, carry := bits.Add64(lo, uint64(c/2), 0)
+	hi += carry
+	// 高位不小于除数时商超出 64 位，bits.Div64 会 panic
+	if hi >= uint64(c) {
+		if neg {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	q, _ := bits.Div64(hi, lo, uint64(c))
+	switch {
+	case neg && q >= 1<<63:
+		return math.MinInt64
+	case !neg && q > math.MaxInt64:
+		return math.MaxInt64
+	case neg:
+		return -int64(q)
+	}
+	return int64(q)
+}
+
+// isqrt 返回 n 的整数平方根（向下取整），使用牛顿迭代从上方收敛。
+func isqrt(n uint64) uint64 {
+	if n < 2 {
+		return n
+	}
+	x := uint64(1) << ((bits.Len64(n) + 1) / 2)
+	for {
+		y := (x + n/x) / 2
+		if y >= x {
+			return x
+		}
+		x = y
+	}
+}
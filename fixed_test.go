@@ -0,0 +1,272 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// abs32 返回 int32 的绝对值。
+func abs32(x int32) int32 {
+	return max(x, -x)
+}
+
+func TestAngleSinCos(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        Angle
+		sin, cos int32
+	}{
+		{"zero", 0, 0, FixedOne},
+		{"eighth", AngleFull / 8, 46341, 46341},
+		{"quarter", AngleQuarter, FixedOne, 0},
+		{"half", AngleHalf, 0, -FixedOne},
+		{"three quarters", 3 * AngleQuarter, -FixedOne, 0},
+		{"negative quarter", -AngleQuarter, -FixedOne, 0},
+		{"beyond full turn", AngleFull + AngleQuarter, FixedOne, 0},
+		{"30 degrees", AngleFromDegrees(30), 32766, 56757}, // 取整为 5461，略小于 30°
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if sin, cos := tt.a.Sin(), tt.a.Cos(); sin != tt.sin || cos != tt.cos {
+				t.Fatalf("Sin(), Cos() = %d, %d, want %d, %d", sin, cos, tt.sin, tt.cos)
+			}
+		})
+	}
+}
+
+// TestAngleSinCosMatchesFloat 校验查找表在整个角度范围内与 math.Sin、math.Cos 相差不超过半个 Q16 单位。
+func TestAngleSinCosMatchesFloat(t *testing.T) {
+	for a := -AngleFull - 7; a < AngleFull+7; a += 3 {
+		if want := math.Sin(a.Radians()) * FixedOne; math.Abs(float64(a.Sin())-want) > 0.5+1e-6 {
+			t.Fatalf("Angle(%d).Sin() = %d, want %.3f", a, a.Sin(), want)
+		}
+		if want := math.Cos(a.Radians()) * FixedOne; math.Abs(float64(a.Cos())-want) > 0.5+1e-6 {
+			t.Fatalf("Angle(%d).Cos() = %d, want %.3f", a, a.Cos(), want)
+		}
+	}
+}
+
+func TestAtan2Fixed(t *testing.T) {
+	tests := []struct {
+		name string
+		z, x int64
+		want Angle
+	}{
+		{"zero vector", 0, 0, 0},
+		{"positive X", 0, 1, 0},
+		{"positive Z", 1, 0, AngleQuarter},
+		{"negative X", 0, -5, AngleHalf},
+		{"negative Z", -1, 0, -AngleQuarter},
+		{"diagonal", 7, 7, AngleFull / 8},
+		{"third quadrant diagonal", -3, -3, -3 * AngleFull / 8},
+		{"large vector", 1 << 50, -7, AngleQuarter},
+		{"just below negative X", -1, -1000000, AngleHalf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Atan2Fixed(tt.z, tt.x); got != tt.want {
+				t.Fatalf("Atan2Fixed(%d, %d) = %d, want %d", tt.z, tt.x, got, tt.want)
+			}
+		})
+	}
+	rng := rand.New(rand.NewPCG(21, 0))
+	for range 5000 {
+		z, x := rng.Int64N(1<<40)-1<<39, rng.Int64N(1<<40)-1<<39
+		want := math.Atan2(float64(z), float64(x)) * float64(AngleFull) / (2 * math.Pi)
+		if d := math.Abs(float64(Atan2Fixed(z, x)) - want); d > 1 && d < float64(AngleFull)-1 {
+			t.Fatalf("Atan2Fixed(%d, %d) = %d, want %.3f", z, x, Atan2Fixed(z, x), want)
+		}
+	}
+}
+
+func TestAngleConversion(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Angle
+		want Angle
+	}{
+		{"90 degrees", AngleFromDegrees(90), AngleQuarter},
+		{"-180 degrees", AngleFromDegrees(-180), -AngleHalf},
+		{"1 degree rounds", AngleFromDegrees(1), 182},
+		{"-1 degree rounds away from zero", AngleFromDegrees(-1), -182},
+		{"pi radians", AngleFromRadians(math.Pi), AngleHalf},
+		{"normalize negative", Angle(-1).Normalize(), AngleFull - 1},
+		{"normalize full turn", (AngleFull + 5).Normalize(), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVectorRotateFixed(t *testing.T) {
+	v := Vector{X: 10000, Z: 0}
+	tests := []struct {
+		name  string
+		v     Vector
+		angle Angle
+		want  Vector
+	}{
+		{"quarter turn", v, AngleQuarter, Vector{X: 0, Z: 10000}},
+		{"negative quarter turn", v, -AngleQuarter, Vector{X: 0, Z: -10000}},
+		{"half turn", Vector{X: 3, Z: 4}, AngleHalf, Vector{X: -3, Z: -4}},
+		{"eighth turn", v, AngleFull / 8, Vector{X: 7071, Z: 7071}},
+		{"full turn", Vector{X: -123, Z: 456}, AngleFull, Vector{X: -123, Z: 456}},
+		{"zero vector", Vector{}, AngleQuarter, Vector{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.RotateFixed(tt.angle); got != tt.want {
+				t.Fatalf("RotateFixed(%d) = %v, want %v", tt.angle, got, tt.want)
+			}
+		})
+	}
+	rng := rand.New(rand.NewPCG(22, 0))
+	for range 2000 {
+		v := Vector{X: rng.Int32N(200001) - 100000, Z: rng.Int32N(200001) - 100000}
+		rad := (rng.Float64()*2 - 1) * 2 * math.Pi
+		f, g := v.Rotate(rad), v.RotateFixed(AngleFromRadians(rad))
+		// 角度量化误差 2π/65536 与取整误差叠加，长度 1.5e5 时偏差不超过约 10 个单位
+		if abs32(f.X-g.X) > 10 || abs32(f.Z-g.Z) > 10 {
+			t.Fatalf("%v.RotateFixed(%v) = %v, Rotate = %v", v, rad, g, f)
+		}
+		w := Vector{X: rng.Int32N(2001) - 1000, Z: rng.Int32N(2001) - 1000}
+		if d := v.GetAngleFixed(&w) - AngleFromRadians(v.GetAngle(&w)); d > 1 || d < -1 {
+			t.Fatalf("%v.GetAngleFixed(%v) differs from GetAngle by %d", v, w, d)
+		}
+	}
+}
+
+func TestCalCoordDstFixed(t *testing.T) {
+	tests := []struct {
+		name          string
+		v             Vector
+		start, target Coord
+		want          int64
+	}{
+		{"perpendicular", Vector{10, 0}, Coord{0, 0}, Coord{5, -7}, 7},
+		{"on line", Vector{3, 4}, Coord{1, 1}, Coord{7, 9}, 0},
+		{"rounds half up", Vector{1, 1}, Coord{0, 0}, Coord{0, 5}, 4}, // 3.54
+		{"zero vector", Vector{}, Coord{0, 0}, Coord{5, 5}, 0},
+		{"extreme", Vector{math.MaxInt32, 0}, Coord{math.MinInt32, math.MinInt32}, Coord{math.MaxInt32, math.MaxInt32}, 1<<32 - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.CalCoordDstFixed(tt.start, tt.target); got != tt.want {
+				t.Fatalf("CalCoordDstFixed() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	rng := rand.New(rand.NewPCG(1, 0))
+	for range 1000 {
+		v := Vector{rng.Int32() - 1<<30, rng.Int32() - 1<<30}
+		a := Coord{rng.Int32() - 1<<30, rng.Int32() - 1<<30}
+		b := Coord{rng.Int32() - 1<<30, rng.Int32() - 1<<30}
+		want := v.CalCoordDst(a, b)
+		if got := v.CalCoordDstFixed(a, b); math.Abs(float64(got)-want) > 1+want*1e-9 {
+			t.Fatalf("%v.CalCoordDstFixed(%v, %v) = %d, want %.3f", v, a, b, got, want)
+		}
+	}
+}
+
+func TestFixedHelpers(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want int64
+	}{
+		{"isqrt below square", int64(isqrt(99)), 9},
+		{"isqrt square", int64(isqrt(100)), 10},
+		{"isqrt large", int64(isqrt(1 << 62)), 1 << 31},
+		{"isqrt max", int64(isqrt(math.MaxUint64)), 1<<32 - 1},
+		{"divRound negative half", divRound(-7, 2), -4},
+		{"divRound positive", divRound(7, 3), 2},
+		{"mulDivRound negative", mulDivRound(-7, 3, 2), -11},
+		{"mulDivRound 128-bit product", mulDivRound(1<<40, 1<<40, 1<<30), 1 << 50},
+		{"mulDivRound MinInt64", mulDivRound(math.MinInt64, 1, 1), math.MinInt64},
+		{"mulDivRound MinInt64 negated", mulDivRound(math.MinInt64, -1, 1), math.MaxInt64},
+		{"mulDivRound quotient overflow", mulDivRound(math.MaxInt64, math.MaxInt64, 3), math.MaxInt64},
+		{"mulDivRound negative quotient overflow", mulDivRound(math.MinInt64, math.MaxInt64, 2), math.MinInt64},
+		{"mulDivRound MaxInt64 in range", mulDivRound(math.MaxInt64, 2, 2), math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFixedArcsMatchFloat 校验定点版本的圆弧、螺旋线与绕行路径与浮点版本的采样点数一致、位置相差数个单位以内。
+func TestFixedArcsMatchFloat(t *testing.T) {
+	start, center := Coord{X: 1000, Z: 0}, Coord{}
+	tests := []struct {
+		name      string
+		float     []Coord
+		fixed     []Coord
+		tolerance int32
+	}{
+		{"half arc", GetArcCoords(start, center, math.Pi), GetArcCoordsFixed(start, center, AngleFromRadians(math.Pi)), 2},
+		{"negative arc", GetArcCoords(start, center, -1.25), GetArcCoordsFixed(start, center, AngleFromRadians(-1.25)), 2},
+		{"short arc", GetArcCoords(start, center, 0.05), GetArcCoordsFixed(start, center, AngleFromRadians(0.05)), 2},
+		{"full arc", GetArcCoords(start, center, 2*math.Pi), GetArcCoordsFixed(start, center, AngleFromRadians(2*math.Pi)), 2},
+		{"around 12", GetCoordsAround2(start, center, 12), GetCoordsAround2Fixed(start, center, 12), 2},
+		{"spiral", GetSpiralCoords(start, center, 3.25, 500), GetSpiralCoordsFixed(start, center, AngleFromRadians(3.25), 500), 3},
+		{"spiral degenerates to line", GetSpiralCoords(start, center, 0.1, 500), GetSpiralCoordsFixed(start, center, AngleFromRadians(0.1), 500), 0},
+		{"around to tangent", GetCoordsAround(start, Coord{X: -3000, Z: 2000}, center), GetCoordsAroundFixed(start, Coord{X: -3000, Z: 2000}, center), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.float) != len(tt.fixed) {
+				t.Fatalf("len = %d, want %d", len(tt.fixed), len(tt.float))
+			}
+			for i := range tt.float {
+				if abs32(tt.float[i].X-tt.fixed[i].X) > tt.tolerance || abs32(tt.float[i].Z-tt.fixed[i].Z) > tt.tolerance {
+					t.Fatalf("point %d = %v, want %v", i, tt.fixed[i], tt.float[i])
+				}
+			}
+		})
+	}
+}
+
+// TestSectorFixedMatchesFloat 校验随机场景下 SectorFixed 与 Sector 的判定结果几乎一致，包围盒相差不超过 2 个单位。
+// 两者的边界方向存在取整差异，恰好落在边界附近的极少数点允许判定不同。
+func TestSectorFixedMatchesFloat(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 0))
+	mismatches, total := 0, 0
+	for range 300 {
+		c := Coord{rng.Int32N(2000) - 1000, rng.Int32N(2000) - 1000}
+		r := 50 + rng.Int32N(500)
+		f := Vector{rng.Int32N(200) - 100, rng.Int32N(200) - 100}
+		half := Angle(rng.Int32N(int32(AngleHalf) + 2000))
+		sf := NewSectorFixed(c, r, f, half)
+		s := NewSector(c, r, f, half.Radians())
+		for range 50 {
+			p := Coord{c.X + rng.Int32N(1200) - 600, c.Z + rng.Int32N(1200) - 600}
+			seg := NewSegment(p, Coord{p.X + rng.Int32N(800) - 400, p.Z + rng.Int32N(800) - 400})
+			circle := NewCirCle(p, rng.Int32N(100))
+			total += 3
+			for _, same := range []bool{
+				sf.IsCoordInside(p) == s.IsCoordInside(p),
+				sf.IntersectSegment(seg) == s.IntersectSegment(seg),
+				sf.IntersectCircle(circle) == s.IntersectCircle(circle),
+			} {
+				if !same {
+					mismatches++
+				}
+			}
+		}
+		x0, z0, x1, z1 := sf.ToRect()
+		a0, b0, a1, b1 := s.ToRect()
+		if abs32(x0-a0) > 2 || abs32(z0-b0) > 2 || abs32(x1-a1) > 2 || abs32(z1-b1) > 2 {
+			t.Fatalf("%v ToRect() = %d, %d, %d, %d, float version %d, %d, %d, %d", sf, x0, z0, x1, z1, a0, b0, a1, b1)
+		}
+	}
+	if mismatches > total/200 {
+		t.Fatalf("%d of %d checks differ from the float version", mismatches, total)
+	}
+}
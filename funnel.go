@@ -0,0 +1,201 @@
+package geo
+
+// funnelCorner 表示拉绳路径上的一个拐点及其所属的漏斗入口下标。
+// 入口下标以起点为 0、各入口边依次为 1..n、终点为 n+1 计数。
+type funnelCorner struct {
+	Vertice
+	portal int
+}
+
+// StringPull 使用简单漏斗算法（Simple Stupid Funnel Algorithm）求出穿过多边形走廊的最短拉直路径。
+// portals 为 PathFinder.FindPath 返回的入口边，Vertices[0] 为左端点、Vertices[1] 为右端点。
+// 返回值以 start 开始、end 结束，中间为路径必须绕过的入口边端点（拐点）。
+// 全部方向判断基于整数叉积，相同输入在任何平台上都得到相同结果，适合服务端确定性计算。
+func StringPull(start, end Coord, portals []Edge) []Coord {
+	return cornersToCoords(stringPull(start, end, portals))
+}
+
+// StringPullAndCache 与 StringPull 相同，并将每条入口边两侧的拐点写入寻路器边表中对应边的 Edge.Inflects，
+// 同时写回 portals 中的副本：Inflects[0] 为穿过该入口前的最后一个拐点，Inflects[1] 为穿过后的第一个拐点，
+// 起点与终点以 Index 为 -1 的顶点表示。入口边按两端顶点序号（GenEdgeKey）映射回边序号，未提供边表时只写回 portals。
+// 拐点取决于起点与终点，缓存只记录最近一次经过该边的路径，供路径回放、调试绘制等读取；
+// 漏斗算法本身为线性复杂度，StringPull 不读取缓存。
+func (pf *PathFinder) StringPullAndCache(start, end Coord, portals []Edge) []Coord {
+	corners := stringPull(start, end, portals)
+	j := 0
+	for i := range portals {
+		// 入口边 i 在漏斗中的下标为 i+1，找到跨越它的路径段 corners[j]→corners[j+1]
+		for corners[j+1].portal < i+1 {
+			j++
+		}
+		portals[i].Inflects = [2]Vertice{corners[j].Vertice, corners[j+1].Vertice}
+		if id, ok := pf.keys[portals[i].GenKey()]; ok {
+			if e := pf.edge(id); e != nil {
+				e.Inflects = portals[i].Inflects
+			}
+		}
+	}
+	return cornersToCoords(corners)
+}
+
+// StringPullWithRadius 为半径为 radius 的单位生成穿过多边形走廊的平滑路径。
+// 先通过 ShrinkPortal 将每条入口边两端各向内收缩 radius，使路径与墙角保持间距，
+// 再对收缩后的走廊执行漏斗算法；每个拐点都替换为以原始墙角为圆心、radius 为半径的圆弧：
+// 圆弧起点为上一路径点到圆的切点，终点由 GetCoordsAround 求出指向下一拐点的切点。
+// radius <= 0 时等同于 StringPull。
+func StringPullWithRadius(start, end Coord, portals []Edge, radius int32) []Coord {
+	if radius <= 0 {
+		return StringPull(start, end, portals)
+	}
+	// 记录原始墙角坐标，收缩后的顶点保留原顶点序号用于回查
+	origins := make(map[int32]Coord, len(portals)*2)
+	shrunk := make([]Edge, len(portals))
+	for i, p := range portals {
+		origins[p.Vertices[0].Index] = p.Vertices[0].Coord
+		origins[p.Vertices[1].Index] = p.Vertices[1].Coord
+		shrunk[i], _ = ShrinkPortal(p, radius)
+	}
+
+	corners := stringPull(start, end, shrunk)
+	path := []Coord{start}
+	for i := 1; i < len(corners)-1; i++ {
+		c := corners[i]
+		center, ok := origins[c.Index]
+		prev := path[len(path)-1]
+		if !ok || CalDstCoordToCoord(prev, center) <= float64(radius) {
+			path = append(path, c.Coord)
+			continue
+		}
+		next := corners[i+1].Coord
+		// 从上一路径点出发的切点：墙角在路径左侧时路径从右侧绕过，切点由 center→prev 逆时针旋转得到
+		angle := GetCutOffCoordAngle(prev, center, float64(radius))
+		if orient(prev, center, next) < 0 {
+			angle = -angle
+		}
+		vec := NewVector(center, prev)
+		vec = vec.Rotate(angle)
+		vec = vec.Trunc(float64(radius) / vec.Length())
+		entry := vec.ToCoord(center)
+		for _, p := range GetCoordsAround(entry, next, center) {
+			if p != path[len(path)-1] {
+				path = append(path, p)
+			}
+		}
+	}
+	if end != path[len(path)-1] {
+		path = append(path, end)
+	}
+	return path
+}
+
+// ShrinkPortal 将入口边的两个端点各沿边方向向内移动 radius，返回收缩后的入口边。
+// 顶点序号保持不变以便回查原始墙角；入口宽度不足 2*radius 时两端收缩至中点并返回 false。
+func ShrinkPortal(e Edge, radius int32) (Edge, bool) {
+	left, right := e.Vertices[0].Coord, e.Vertices[1].Coord
+	length := CalDstCoordToCoord(left, right)
+	if length < 2*float64(radius) {
+		mid := e.CalMidCoord()
+		e.Vertices[0].Coord = mid
+		e.Vertices[1].Coord = mid
+		return e, false
+	}
+	if length == 0 {
+		return e, true
+	}
+	ratio := float64(radius) / length
+	e.Vertices[0].Coord = CalCoordByRatio(left, right, ratio)
+	e.Vertices[1].Coord = CalCoordByRatio(right, left, ratio)
+	return e, true
+}
+
+// stringPull 执行漏斗算法，返回带入口下标的拐点序列。
+// 漏斗由顶点 apex 与左右两条边界 apex→left、apex→right 组成，依次处理每个入口：
+//   - 新右端点位于当前右边界左侧（收紧漏斗）时，若未越过左边界则更新右边界，
+//     否则左边界端点成为新的拐点与漏斗顶点，并从该点所属入口之后重新开始；
+//   - 左端点的处理与之对称。
+func stringPull(start, end Coord, portals []Edge) []funnelCorner {
+	startV := Vertice{Index: -1, Coord: start}
+	endV := Vertice{Index: -1, Coord: end}
+	n := len(portals) + 2
+	leftAt := func(i int) Vertice {
+		switch i {
+		case 0:
+			return startV
+		case n - 1:
+			return endV
+		}
+		return portals[i-1].Vertices[0]
+	}
+	rightAt := func(i int) Vertice {
+		switch i {
+		case 0:
+			return startV
+		case n - 1:
+			return endV
+		}
+		return portals[i-1].Vertices[1]
+	}
+
+	corners := []funnelCorner{{Vertice: startV, portal: 0}}
+	apex, left, right := startV, startV, startV
+	apexIdx, leftIdx, rightIdx := 0, 0, 0
+	for i := 1; i < n; i++ {
+		l, r := leftAt(i), rightAt(i)
+
+		// 更新右边界：新右端点在 apex→right 左侧或共线时漏斗收紧
+		if orient(apex.Coord, right.Coord, r.Coord) >= 0 {
+			if apex.Coord == right.Coord || orient(apex.Coord, left.Coord, r.Coord) < 0 {
+				right, rightIdx = r, i
+			} else {
+				// 右边界越过左边界：左端点成为新的拐点
+				corners = appendCorner(corners, left, leftIdx)
+				apex, apexIdx = left, leftIdx
+				left, leftIdx = apex, apexIdx
+				right, rightIdx = apex, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+
+		// 更新左边界：新左端点在 apex→left 右侧或共线时漏斗收紧
+		if orient(apex.Coord, left.Coord, l.Coord) <= 0 {
+			if apex.Coord == left.Coord || orient(apex.Coord, right.Coord, l.Coord) > 0 {
+				left, leftIdx = l, i
+			} else {
+				// 左边界越过右边界：右端点成为新的拐点
+				corners = appendCorner(corners, right, rightIdx)
+				apex, apexIdx = right, rightIdx
+				left, leftIdx = apex, apexIdx
+				right, rightIdx = apex, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+	}
+	corners = appendCorner(corners, endV, n-1)
+	return corners
+}
+
+// appendCorner 追加拐点，与末尾拐点坐标相同时仅更新其入口下标。
+func appendCorner(corners []funnelCorner, v Vertice, portal int) []funnelCorner {
+	last := &corners[len(corners)-1]
+	if last.Coord == v.Coord {
+		last.portal = portal
+		return corners
+	}
+	return append(corners, funnelCorner{Vertice: v, portal: portal})
+}
+
+// cornersToCoords 提取拐点序列的坐标。
+func cornersToCoords(corners []funnelCorner) []Coord {
+	coords := make([]Coord, len(corners))
+	for i, c := range corners {
+		coords[i] = c.Coord
+	}
+	return coords
+}
+
+// orient 返回 a→b 与 a→c 的叉积符号，> 0 表示 c 在 a→b 的左侧（逆时针方向），由 Orient2D 精确判定。
+func orient(a, b, c Coord) int64 {
+	return cross(b, c, a)
+}
@@ -0,0 +1,136 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStringPull(t *testing.T) {
+	holeCorners := []Coord{{200, 200}, {800, 200}, {800, 800}, {200, 800}}
+	tests := []struct {
+		name       string
+		start, end Coord
+		points     int // 路径点数量（含起点与终点），中间拐点均为方洞的角点
+	}{
+		{"same polygon", Coord{100, 60}, Coord{120, 70}, 2},
+		{"straight along corridor", Coord{100, 60}, Coord{900, 150}, 2},
+		{"around one corner", Coord{100, 60}, Coord{900, 900}, 3},
+		{"around two corners", Coord{100, 500}, Coord{900, 500}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf, _ := testPathFinder(t, false)
+			_, portals, ok := pf.FindPath(tt.start, tt.end)
+			if !ok {
+				t.Fatal("FindPath() ok = false")
+			}
+			path := StringPull(tt.start, tt.end, portals)
+			if len(path) != tt.points {
+				t.Fatalf("StringPull() = %v, want %d points", path, tt.points)
+			}
+			if path[0] != tt.start || path[len(path)-1] != tt.end {
+				t.Fatalf("StringPull() = %v, want it to run from %v to %v", path, tt.start, tt.end)
+			}
+			for _, c := range path[1 : len(path)-1] {
+				if !slices.Contains(holeCorners, c) {
+					t.Fatalf("corner %v is not a hole corner", c)
+				}
+			}
+		})
+	}
+}
+
+func TestStringPullAndCache(t *testing.T) {
+	pf, m := testPathFinder(t, false)
+	start, end := Coord{100, 500}, Coord{900, 500}
+	_, portals, ok := pf.FindPath(start, end)
+	if !ok {
+		t.Fatal("FindPath() ok = false")
+	}
+	path := pf.StringPullAndCache(start, end, portals)
+	if want := StringPull(start, end, portals); !slices.Equal(path, want) {
+		t.Fatalf("StringPullAndCache() = %v, want %v", path, want)
+	}
+	for i, p := range portals {
+		_, id, ok := m.FindEdge(p.Vertices[0].Index, p.Vertices[1].Index)
+		if !ok {
+			t.Fatalf("portal %d has no mesh edge", i)
+		}
+		if m.Edges[id].Inflects != p.Inflects {
+			t.Fatalf("edge %d Inflects = %v, want %v", id, m.Edges[id].Inflects, p.Inflects)
+		}
+		// 拐点须依次出现在路径上，起点与终点的 Index 为 -1
+		for _, v := range p.Inflects {
+			if !slices.Contains(path, v.Coord) {
+				t.Fatalf("portal %d inflect %v is not on path %v", i, v.Coord, path)
+			}
+			if (v.Coord == start || v.Coord == end) && v.Index != -1 {
+				t.Fatalf("portal %d inflect %v has Index %d, want -1", i, v.Coord, v.Index)
+			}
+		}
+	}
+}
+
+func TestShrinkPortal(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right Coord
+		radius      int32
+		want        [2]Coord
+		ok          bool
+	}{
+		{"zero radius", Coord{0, 0}, Coord{100, 0}, 0, [2]Coord{{0, 0}, {100, 0}}, true},
+		{"shrink both ends", Coord{0, 0}, Coord{100, 0}, 10, [2]Coord{{10, 0}, {90, 0}}, true},
+		{"exactly two radii", Coord{0, 0}, Coord{0, 100}, 50, [2]Coord{{0, 50}, {0, 50}}, true},
+		{"too narrow", Coord{0, 0}, Coord{100, 0}, 60, [2]Coord{{50, 0}, {50, 0}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Edge{Vertices: [2]Vertice{{Index: 1, Coord: tt.left}, {Index: 2, Coord: tt.right}}}
+			got, ok := ShrinkPortal(e, tt.radius)
+			if ok != tt.ok || got.Vertices[0].Coord != tt.want[0] || got.Vertices[1].Coord != tt.want[1] {
+				t.Fatalf("ShrinkPortal() = %v, %v, want %v, %v", got.Vertices, ok, tt.want, tt.ok)
+			}
+			if got.Vertices[0].Index != 1 || got.Vertices[1].Index != 2 {
+				t.Fatalf("ShrinkPortal() changed vertex indices to %d, %d", got.Vertices[0].Index, got.Vertices[1].Index)
+			}
+		})
+	}
+}
+
+func TestStringPullWithRadius(t *testing.T) {
+	holeCorners := []Coord{{200, 200}, {800, 200}, {800, 800}, {200, 800}}
+	tests := []struct {
+		name       string
+		start, end Coord
+		radius     int32
+	}{
+		{"zero radius", Coord{100, 500}, Coord{900, 500}, 0},
+		{"around two corners", Coord{100, 500}, Coord{900, 500}, 50},
+		{"around one corner", Coord{100, 60}, Coord{900, 900}, 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf, _ := testPathFinder(t, false)
+			_, portals, ok := pf.FindPathWithRadius(tt.start, tt.end, tt.radius)
+			if !ok {
+				t.Fatal("FindPathWithRadius() ok = false")
+			}
+			path := StringPullWithRadius(tt.start, tt.end, portals, tt.radius)
+			if tt.radius == 0 && !slices.Equal(path, StringPull(tt.start, tt.end, portals)) {
+				t.Fatalf("StringPullWithRadius() = %v, want StringPull result", path)
+			}
+			if path[0] != tt.start || path[len(path)-1] != tt.end {
+				t.Fatalf("StringPullWithRadius() = %v, want it to run from %v to %v", path, tt.start, tt.end)
+			}
+			// 圆弧上的点由 Vector.Rotate 逐点截断取整，允许 3 个单位的误差
+			for _, p := range path {
+				for _, c := range holeCorners {
+					if d := CalDstCoordToCoord(p, c); d < float64(tt.radius)-3 {
+						t.Fatalf("path point %v is %.1f from corner %v, want at least %d", p, d, c, tt.radius)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,508 @@
+package geo
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// 本文件提供以数值类型为参数的泛型图元，使同一套算法同时服务于三种精度：
+// int32（与 Coord 等原有类型一致）、int64（超大世界分片）与 float64（编辑器中以米为单位的浮点坐标）。
+// 原有的 int32 类型保持不变，二者之间通过 ToCoordT 与 CoordT.Coord 等显式转换互通；
+// 不同精度之间通过 ConvertCoord 等函数转换，舍入或越界时返回 ErrPrecisionLoss / ErrOutOfRange。
+// 方向与线段相交判定（OrientT、IsSegmentCrossT）对三种精度都精确且不分配内存；
+// 交点构造与圆、矩形判定以 math/big 精确计算，没有溢出与舍入误差，但比 int32 专用版本慢，
+// 高频路径仍应使用原有类型。浮点坐标必须为有限值。
+
+// ErrPrecisionLoss 表示数值类型转换丢失了精度（小数部分被舍入，或 int64 超出 float64 的精确整数范围）
+var ErrPrecisionLoss = errors.New("geo: conversion loses precision")
+
+// Number 为泛型图元支持的坐标分量类型。
+type Number interface {
+	int32 | int64 | float64
+}
+
+// CoordT 表示分量类型为 T 的二维坐标点，CoordT[int32] 与 Coord 的内存布局相同。
+type CoordT[T Number] struct {
+	X T `json:"x"`
+	Z T `json:"z"`
+}
+
+// VectorT 表示分量类型为 T 的二维向量。
+type VectorT[T Number] struct {
+	X T
+	Z T
+}
+
+// SegmentT 表示端点分量类型为 T 的线段。
+type SegmentT[T Number] struct {
+	A CoordT[T]
+	B CoordT[T]
+}
+
+// CircleT 表示圆心与半径类型为 T 的圆形。
+type CircleT[T Number] struct {
+	Center CoordT[T]
+	Radius T
+}
+
+// RectangleT 表示分量类型为 T 的轴对齐矩形，由左下角坐标及宽高描述。
+type RectangleT[T Number] struct {
+	CoordT[T]   // 左下角坐标（锚点）
+	Width     T // 沿 X 轴方向的宽度
+	Height    T // 沿 Z 轴方向的高度
+}
+
+// 常用精度的类型别名：F 后缀为 float64，64 后缀为 int64。
+type (
+	CoordF      = CoordT[float64]
+	Coord64     = CoordT[int64]
+	VectorF     = VectorT[float64]
+	Vector64    = VectorT[int64]
+	SegmentF    = SegmentT[float64]
+	Segment64   = SegmentT[int64]
+	CircleF     = CircleT[float64]
+	Circle64    = CircleT[int64]
+	RectangleF  = RectangleT[float64]
+	Rectangle64 = RectangleT[int64]
+)
+
+// NewVectorT 创建从 start 指向 end 的向量，是 NewVector 的泛型版本。
+func NewVectorT[T Number](start, end CoordT[T]) VectorT[T] {
+	return VectorT[T]{X: end.X - start.X, Z: end.Z - start.Z}
+}
+
+// ToCoord 将向量作为位移加到起点坐标，返回终点坐标。
+func (v VectorT[T]) ToCoord(start CoordT[T]) CoordT[T] {
+	return CoordT[T]{X: start.X + v.X, Z: start.Z + v.Z}
+}
+
+// Length 计算向量的长度。
+func (v VectorT[T]) Length() float64 {
+	return math.Hypot(float64(v.X), float64(v.Z))
+}
+
+// DistanceTo 计算两点之间的欧几里得距离，分量先转换为 float64 再相减，整数分量不会溢出。
+func (c CoordT[T]) DistanceTo(target CoordT[T]) float64 {
+	return math.Hypot(float64(target.X)-float64(c.X), float64(target.Z)-float64(c.Z))
+}
+
+// OrientT 是 Orient2D 的泛型版本：返回 1 表示 c 在有向直线 a→b 左侧，-1 表示右侧，0 表示三点共线。
+// 对三种精度都精确计算且不分配内存：int32 坐标直接使用 Orient2D，int64 坐标以 128 位整数计算，
+// float64 坐标先以误差界快速判定，无法确定时再以浮点展开（expansion）精确求值。
+func OrientT[T Number](a, b, c CoordT[T]) int {
+	switch a := any(a).(type) {
+	case CoordT[int32]:
+		b, c := any(b).(CoordT[int32]), any(c).(CoordT[int32])
+		return Orient2D(Coord(a), Coord(b), Coord(c))
+	case CoordT[int64]:
+		b, c := any(b).(CoordT[int64]), any(c).(CoordT[int64])
+		return orientInt64(a, b, c)
+	}
+	af, bf, cf := any(a).(CoordT[float64]), any(b).(CoordT[float64]), any(c).(CoordT[float64])
+	return orientFloat64(af, bf, cf)
+}
+
+// IsSegmentCrossT 是 isSegmentCross 的泛型版本，精确判断线段 P0P1 与 Q0Q1 是否有公共点（含端点接触与共线重叠），
+// 端点仅落在另一线段延长线上时不算相交。方向判定由 OrientT 完成，不分配内存。
+func IsSegmentCrossT[T Number](p0, p1, q0, q1 CoordT[T]) bool {
+	d1, d2 := OrientT(q0, q1, p0), OrientT(q0, q1, p1)
+	d3, d4 := OrientT(p0, p1, q0), OrientT(p0, p1, q1)
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return (d1 == 0 && isCoordInRectT(q0, q1, p0)) ||
+		(d2 == 0 && isCoordInRectT(q0, q1, p1)) ||
+		(d3 == 0 && isCoordInRectT(p0, p1, q0)) ||
+		(d4 == 0 && isCoordInRectT(p0, p1, q1))
+}
+
+// SegmentIntersectionT 是 SegmentIntersection 的泛型版本，以有理数坐标精确返回两线段的唯一交点。
+// 需要某一精度的坐标时调用 ConvertRatCoord。
+func SegmentIntersectionT[T Number](p0, p1, q0, q1 CoordT[T]) (RatCoord, bool) {
+	if !IsSegmentCrossT(p0, p1, q0, q1) {
+		return RatCoord{}, false
+	}
+	s1, s2 := ratSub(p1, p0), ratSub(q1, q0)
+	den := ratCross(s1, s2)
+	if den.Sign() == 0 {
+		// 共线：收集落在另一线段上的端点，去重后恰为一个点才是唯一交点
+		var touch []CoordT[T]
+		for _, c := range [4][3]CoordT[T]{{q0, q1, p0}, {q0, q1, p1}, {p0, p1, q0}, {p0, p1, q1}} {
+			if isCoordInRectT(c[0], c[1], c[2]) && (len(touch) == 0 || touch[0] != c[2]) {
+				touch = append(touch, c[2])
+			}
+		}
+		if len(touch) != 1 {
+			return RatCoord{}, false
+		}
+		return RatCoord{X: ratOf(touch[0].X), Z: ratOf(touch[0].Z)}, true
+	}
+	// 交点 = P0 + t·S1，t = [(Q0 - P0) × S2] / (S1 × S2)
+	t := new(big.Rat).Quo(ratCross(ratSub(q0, p0), s2), den)
+	axis := func(from T, s *big.Rat) *big.Rat {
+		v := new(big.Rat).Mul(t, s)
+		return v.Add(v, ratOf(from))
+	}
+	return RatCoord{X: axis(p0.X, s1[0]), Z: axis(p0.Z, s1[1])}, true
+}
+
+// IsCoordInside 判断点是否在圆内（含圆周），精确计算。
+func (c CircleT[T]) IsCoordInside(p CoordT[T]) bool {
+	r := ratOf(c.Radius)
+	return ratDot(ratSub(p, c.Center), ratSub(p, c.Center)).Cmp(new(big.Rat).Mul(r, r)) <= 0
+}
+
+// IsInterSegment 判断线段与圆形区域是否相交（线段完全在圆内也视为相交），精确计算。
+// 与 Circle.IsIntersect 只判断线段是否穿过圆周不同，本方法比较的是圆心到线段的最短距离与半径。
+func (c CircleT[T]) IsInterSegment(s SegmentT[T]) bool {
+	d, w := ratSub(s.B, s.A), ratSub(c.Center, s.A)
+	r := ratOf(c.Radius)
+	r2 := new(big.Rat).Mul(r, r)
+	t, dd := ratDot(w, d), ratDot(d, d)
+	switch {
+	case t.Sign() <= 0:
+		// 垂足在 A 之外（或线段退化为点），最近点为 A
+		return ratDot(w, w).Cmp(r2) <= 0
+	case t.Cmp(dd) >= 0:
+		// 垂足在 B 之外，最近点为 B
+		return c.IsCoordInside(s.B)
+	}
+	// 圆心到直线距离的平方为 (w×d)² / |d|²，两边同乘 |d|² 避免除法
+	cr := ratCross(w, d)
+	return new(big.Rat).Mul(cr, cr).Cmp(r2.Mul(r2, dd)) <= 0
+}
+
+// IsCoordInside 判断点是否在矩形内（含边界），精确计算。
+func (r RectangleT[T]) IsCoordInside(p CoordT[T]) bool {
+	return ratWithin(r.X, r.Width, p.X) && ratWithin(r.Z, r.Height, p.Z)
+}
+
+// IsInterRect 判断两个矩形是否重叠（含边界接触），精确计算。
+func (r RectangleT[T]) IsInterRect(o RectangleT[T]) bool {
+	overlap := func(lo1, size1, lo2, size2 T) bool {
+		hi1 := new(big.Rat).Add(ratOf(lo1), ratOf(size1))
+		hi2 := new(big.Rat).Add(ratOf(lo2), ratOf(size2))
+		return ratOf(lo1).Cmp(hi2) <= 0 && ratOf(lo2).Cmp(hi1) <= 0
+	}
+	return overlap(r.X, r.Width, o.X, o.Width) && overlap(r.Z, r.Height, o.Z, o.Height)
+}
+
+// ConvertNumber 将数值从类型 S 转换为类型 D。
+// 浮点数转换为整数时按包级取整方式 Rounding 取整，丢弃小数部分时返回 ErrPrecisionLoss，
+// 超出目标范围（或为 NaN）时结果饱和到边界值并返回 ErrOutOfRange；
+// int64 转换为 float64 超出 2^53 的精确整数范围而被舍入时返回 ErrPrecisionLoss。
+// 出错时仍返回转换后的近似值，调用方可以选择忽略错误。
+func ConvertNumber[D, S Number](v S) (D, error) {
+	var zero D
+	switch x := any(v).(type) {
+	case float64:
+		switch any(zero).(type) {
+		case float64:
+			return D(x), nil
+		case int64:
+			n, err := Rounding.roundInt64Checked(x)
+			if err == nil && float64(n) != x {
+				err = fmt.Errorf("%w: %g", ErrPrecisionLoss, x)
+			}
+			return D(n), err
+		default:
+			n, err := Rounding.RoundChecked(x)
+			if err == nil && float64(n) != x {
+				err = fmt.Errorf("%w: %g", ErrPrecisionLoss, x)
+			}
+			return D(n), err
+		}
+	case int64:
+		return convertInt[D](x)
+	default:
+		return convertInt[D](int64(any(v).(int32)))
+	}
+}
+
+// convertInt 将 int64 转换为类型 D，规则见 ConvertNumber。
+func convertInt[D Number](x int64) (D, error) {
+	var zero D
+	switch any(zero).(type) {
+	case float64:
+		f := float64(x)
+		// float64(x) 可能舍入到 2^63，此时转换回 int64 的结果依赖实现，需单独判断
+		if f >= 0x1p63 || int64(f) != x {
+			return D(f), fmt.Errorf("%w: %d", ErrPrecisionLoss, x)
+		}
+		return D(f), nil
+	case int64:
+		return D(x), nil
+	default:
+		if x > math.MaxInt32 || x < math.MinInt32 {
+			return D(min(max(x, math.MinInt32), math.MaxInt32)), fmt.Errorf("%w: %d", ErrOutOfRange, x)
+		}
+		return D(x), nil
+	}
+}
+
+// ConvertCoord 将坐标转换为另一种精度，转换规则见 ConvertNumber，两个分量的错误以 errors.Join 合并。
+func ConvertCoord[D, S Number](c CoordT[S]) (CoordT[D], error) {
+	x, errX := ConvertNumber[D](c.X)
+	z, errZ := ConvertNumber[D](c.Z)
+	return CoordT[D]{X: x, Z: z}, errors.Join(errX, errZ)
+}
+
+// ConvertVector 将向量转换为另一种精度，转换规则见 ConvertNumber。
+func ConvertVector[D, S Number](v VectorT[S]) (VectorT[D], error) {
+	c, err := ConvertCoord[D](CoordT[S](v))
+	return VectorT[D](c), err
+}
+
+// ConvertSegment 将线段转换为另一种精度，转换规则见 ConvertNumber。
+func ConvertSegment[D, S Number](s SegmentT[S]) (SegmentT[D], error) {
+	a, errA := ConvertCoord[D](s.A)
+	b, errB := ConvertCoord[D](s.B)
+	return SegmentT[D]{A: a, B: b}, errors.Join(errA, errB)
+}
+
+// ConvertCircle 将圆形转换为另一种精度，转换规则见 ConvertNumber。
+func ConvertCircle[D, S Number](c CircleT[S]) (CircleT[D], error) {
+	center, errC := ConvertCoord[D](c.Center)
+	radius, errR := ConvertNumber[D](c.Radius)
+	return CircleT[D]{Center: center, Radius: radius}, errors.Join(errC, errR)
+}
+
+// ConvertRectangle 将矩形转换为另一种精度，转换规则见 ConvertNumber。
+func ConvertRectangle[D, S Number](r RectangleT[S]) (RectangleT[D], error) {
+	anchor, errC := ConvertCoord[D](r.CoordT)
+	width, errW := ConvertNumber[D](r.Width)
+	height, errH := ConvertNumber[D](r.Height)
+	return RectangleT[D]{CoordT: anchor, Width: width, Height: height}, errors.Join(errC, errW, errH)
+}
+
+// ConvertRatCoord 将有理数精确坐标转换为指定精度：整数按包级取整方式 Rounding 取整，
+// 浮点数取最接近的 float64；不能精确表示时返回 ErrPrecisionLoss，越界时返回 ErrOutOfRange。
+func ConvertRatCoord[T Number](r RatCoord) (CoordT[T], error) {
+	x, errX := convertRat[T](r.X)
+	z, errZ := convertRat[T](r.Z)
+	return CoordT[T]{X: x, Z: z}, errors.Join(errX, errZ)
+}
+
+// convertRat 将有理数转换为类型 T，规则见 ConvertRatCoord。
+func convertRat[T Number](v *big.Rat) (T, error) {
+	var zero T
+	if _, ok := any(zero).(float64); ok {
+		f, exact := v.Float64()
+		if !exact {
+			return T(f), fmt.Errorf("%w: %s", ErrPrecisionLoss, v.RatString())
+		}
+		return T(f), nil
+	}
+	// den > 0 时 DivMod 即向负无穷整除，余数落在 [0, den)
+	q, r := new(big.Int).DivMod(v.Num(), v.Denom(), new(big.Int))
+	if !q.IsInt64() {
+		out, _ := convertInt[T](int64(q.Sign()) * math.MaxInt64)
+		return out, fmt.Errorf("%w: %s", ErrOutOfRange, v.RatString())
+	}
+	frac := r.Sign() != 0
+	out, err := convertInt[T](Rounding.roundFraction(q.Int64(), frac, new(big.Int).Lsh(r, 1).Cmp(v.Denom())))
+	if err == nil && frac {
+		err = fmt.Errorf("%w: %s", ErrPrecisionLoss, v.RatString())
+	}
+	return out, err
+}
+
+// ToCoordT 将 int32 坐标无损转换为指定精度。
+func ToCoordT[T Number](c Coord) CoordT[T] {
+	return CoordT[T]{X: T(c.X), Z: T(c.Z)}
+}
+
+// ToVectorT 将 int32 向量无损转换为指定精度。
+func ToVectorT[T Number](v Vector) VectorT[T] {
+	return VectorT[T]{X: T(v.X), Z: T(v.Z)}
+}
+
+// ToSegmentT 将 int32 线段无损转换为指定精度。
+func ToSegmentT[T Number](s Segment) SegmentT[T] {
+	return SegmentT[T]{A: ToCoordT[T](s.A), B: ToCoordT[T](s.B)}
+}
+
+// ToCircleT 将 int32 圆形无损转换为指定精度。
+func ToCircleT[T Number](c Circle) CircleT[T] {
+	return CircleT[T]{Center: ToCoordT[T](c.Center), Radius: T(c.Radius)}
+}
+
+// ToRectangleT 将 int32 矩形无损转换为指定精度。
+func ToRectangleT[T Number](r Rectangle) RectangleT[T] {
+	return RectangleT[T]{CoordT: ToCoordT[T](r.Coord), Width: T(r.Width), Height: T(r.Height)}
+}
+
+// Coord 将坐标转换为 int32 的 Coord，转换规则见 ConvertNumber。
+func (c CoordT[T]) Coord() (Coord, error) {
+	r, err := ConvertCoord[int32](c)
+	return Coord(r), err
+}
+
+// Vector 将向量转换为 int32 的 Vector，转换规则见 ConvertNumber。
+func (v VectorT[T]) Vector() (Vector, error) {
+	r, err := ConvertVector[int32](v)
+	return Vector(r), err
+}
+
+// Segment 将线段转换为 int32 的 Segment，转换规则见 ConvertNumber。
+func (s SegmentT[T]) Segment() (Segment, error) {
+	r, err := ConvertSegment[int32](s)
+	return Segment{A: Coord(r.A), B: Coord(r.B)}, err
+}
+
+// Circle 将圆形转换为 int32 的 Circle，转换规则见 ConvertNumber。
+func (c CircleT[T]) Circle() (Circle, error) {
+	r, err := ConvertCircle[int32](c)
+	return Circle{Center: Coord(r.Center), Radius: r.Radius}, err
+}
+
+// Rectangle 将矩形转换为 int32 的 Rectangle，转换规则见 ConvertNumber。
+func (r RectangleT[T]) Rectangle() (Rectangle, error) {
+	v, err := ConvertRectangle[int32](r)
+	return Rectangle{Coord: Coord(v.CoordT), Width: v.Width, Height: v.Height}, err
+}
+
+// isCoordInRectT 是 isCoordInRect 的泛型版本。
+func isCoordInRectT[T Number](a, b, p CoordT[T]) bool {
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+		min(a.Z, b.Z) <= p.Z && p.Z <= max(a.Z, b.Z)
+}
+
+// ratOf 将数值精确转换为有理数。
+func ratOf[T Number](v T) *big.Rat {
+	switch x := any(v).(type) {
+	case float64:
+		return new(big.Rat).SetFloat64(x)
+	case int64:
+		return new(big.Rat).SetInt64(x)
+	default:
+		return new(big.Rat).SetInt64(int64(any(v).(int32)))
+	}
+}
+
+// ratSub 精确计算 a - b，以有理数向量返回。
+func ratSub[T Number](a, b CoordT[T]) [2]*big.Rat {
+	return [2]*big.Rat{
+		new(big.Rat).Sub(ratOf(a.X), ratOf(b.X)),
+		new(big.Rat).Sub(ratOf(a.Z), ratOf(b.Z)),
+	}
+}
+
+// ratCross 返回有理数向量的叉积 u×v。
+func ratCross(u, v [2]*big.Rat) *big.Rat {
+	r := new(big.Rat).Mul(u[0], v[1])
+	return r.Sub(r, new(big.Rat).Mul(u[1], v[0]))
+}
+
+// ratDot 返回有理数向量的点积 u·v。
+func ratDot(u, v [2]*big.Rat) *big.Rat {
+	r := new(big.Rat).Mul(u[0], v[0])
+	return r.Add(r, new(big.Rat).Mul(u[1], v[1]))
+}
+
+// ratWithin 精确判断 lo <= v <= lo + size。
+func ratWithin[T Number](lo, size, v T) bool {
+	hi := new(big.Rat).Add(ratOf(lo), ratOf(size))
+	return ratOf(lo).Cmp(ratOf(v)) <= 0 && ratOf(v).Cmp(hi) <= 0
+}
+
+// orientInt64 精确计算 (b-a)×(c-a) 的符号。int64 之差的绝对值不超过 2^64-1，以符号与 uint64 绝对值表示，
+// 两个乘积的绝对值均小于 2^128，比较符号后再比较 128 位绝对值即可。
+func orientInt64(a, b, c CoordT[int64]) int {
+	s1, h1, l1 := mulDiff64(b.X, a.X, c.Z, a.Z)
+	s2, h2, l2 := mulDiff64(b.Z, a.Z, c.X, a.X)
+	if s1 != s2 || s1 == 0 {
+		return cmp.Compare(s1, s2)
+	}
+	// 同号时比较绝对值
+	return s1 * cmp.Or(cmp.Compare(h1, h2), cmp.Compare(l1, l2))
+}
+
+// mulDiff64 返回 (a-b)·(c-d) 的符号与 128 位绝对值。
+func mulDiff64(a, b, c, d int64) (sign int, hi, lo uint64) {
+	abs := func(x, y int64) (int, uint64) {
+		switch {
+		case x > y:
+			return 1, uint64(x) - uint64(y)
+		case x < y:
+			return -1, uint64(y) - uint64(x)
+		}
+		return 0, 0
+	}
+	s1, m1 := abs(a, b)
+	s2, m2 := abs(c, d)
+	hi, lo = bits.Mul64(m1, m2)
+	return s1 * s2, hi, lo
+}
+
+// orientErrBound 为 float64 方向判定快速路径的相对误差界（Shewchuk 的 ccwerrboundA）。
+const orientErrBound = (3 + 16*0x1p-53) * 0x1p-53
+
+// orientFloat64 精确计算 (b-a)×(c-a) 的符号。
+// 先按浮点计算并用误差界判定；无法确定时将行列式展开为六个坐标乘积之和，
+// 以 FMA 求出每个乘积的精确误差项，再用无误差加法累加为非重叠展开，最大分量的符号即为结果。
+// 某个乘积溢出或接近下溢（绝对值超过约 1e308 或小于 2^-900）时展开不再精确，回退到 math/big。
+func orientFloat64(a, b, c CoordT[float64]) int {
+	l := (b.X - a.X) * (c.Z - a.Z)
+	r := (b.Z - a.Z) * (c.X - a.X)
+	det := l - r
+	if sum := math.Abs(l) + math.Abs(r); sum >= 0x1p-900 {
+		if bound := orientErrBound * sum; det > bound {
+			return 1
+		} else if -det > bound {
+			return -1
+		}
+	}
+
+	// det = bx·cz - bx·az - ax·cz - bz·cx + bz·ax + az·cx（ax·az 项相互抵消）
+	terms := [6][3]float64{
+		{b.X, c.Z, 1}, {b.X, a.Z, -1}, {a.X, c.Z, -1},
+		{b.Z, c.X, -1}, {b.Z, a.X, 1}, {a.Z, c.X, 1},
+	}
+	var e [12]float64
+	n := 0
+	for _, t := range terms {
+		p := t[0] * t[1] * t[2]
+		if math.IsInf(p, 0) || p != 0 && math.Abs(p) < 0x1p-900 || p == 0 && t[0] != 0 && t[1] != 0 {
+			return ratCross(ratSub(b, a), ratSub(c, a)).Sign()
+		}
+		n = growExpansion(e[:], n, p)
+		n = growExpansion(e[:], n, math.FMA(t[0]*t[2], t[1], -p))
+	}
+	if n == 0 {
+		return 0
+	}
+	if e[n-1] > 0 {
+		return 1
+	}
+	return -1
+}
+
+// growExpansion 将 x 无误差地累加到按绝对值递增排列的非重叠展开 e[:n]，返回新展开的长度（零分量被移除）。
+func growExpansion(e []float64, n int, x float64) int {
+	q, m := x, 0
+	for _, v := range e[:n] {
+		var h float64
+		q, h = twoSum(q, v)
+		if h != 0 {
+			e[m] = h
+			m++
+		}
+	}
+	if q != 0 {
+		e[m] = q
+		m++
+	}
+	return m
+}
+
+// twoSum 返回 a + b 的浮点结果 s 及其精确误差 err，满足 s + err = a + b。
+func twoSum(a, b float64) (s, err float64) {
+	s = a + b
+	bv := s - a
+	return s, (a - (s - bv)) + (b - bv)
+}
@@ -0,0 +1,237 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestOrientT(t *testing.T) {
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"int32 right", OrientT(CoordT[int32]{0, 0}, CoordT[int32]{1, 0}, CoordT[int32]{5, -1}), -1},
+		{"int64 beyond int32 collinear", OrientT(Coord64{-1 << 62, -1 << 62}, Coord64{1 << 62, 1 << 62}, Coord64{1, 1}), 0},
+		{"int64 beyond int32 left", OrientT(Coord64{-1 << 62, -1 << 62}, Coord64{1 << 62, 1 << 62}, Coord64{0, 1}), 1},
+		{"int64 extreme", OrientT(Coord64{math.MinInt64, math.MinInt64}, Coord64{math.MaxInt64, math.MaxInt64}, Coord64{math.MaxInt64, math.MinInt64}), -1},
+		{"float64 tiny offset", OrientT(CoordF{0, 0}, CoordF{1, 0}, CoordF{0.5, 1e-300}), 1},
+		{"float64 tiny offset right", OrientT(CoordF{0, 0}, CoordF{1, 0}, CoordF{0.5, -1e-300}), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("OrientT() = %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOrientTMatchesBig 以 math/big 校验 int64 极端坐标与 float64 近似共线坐标下 OrientT 的结果，
+// 并确认二者都不分配内存。
+func TestOrientTMatchesBig(t *testing.T) {
+	rng := rand.New(rand.NewPCG(43, 0))
+	i64 := func() int64 {
+		// 一半取值靠近 int64 边界，使坐标差超出 int64 范围
+		if rng.IntN(2) == 0 {
+			return math.MaxInt64 - rng.Int64N(4)
+		}
+		return math.MinInt64 + rng.Int64N(1<<62)
+	}
+	for range 5000 {
+		a, b, c := Coord64{i64(), i64()}, Coord64{i64(), i64()}, Coord64{i64(), i64()}
+		if got, want := OrientT(a, b, c), ratCross(ratSub(b, a), ratSub(c, a)).Sign(); got != want {
+			t.Fatalf("OrientT(%v, %v, %v) = %d, want %d", a, b, c, got, want)
+		}
+	}
+	for range 5000 {
+		// c 取在 ab 上的浮点近似点，行列式接近 0，快速判定无法确定符号
+		a := CoordF{rng.Float64()*2e6 - 1e6, rng.Float64()*2e6 - 1e6}
+		b := CoordF{rng.Float64()*2e6 - 1e6, rng.Float64()*2e6 - 1e6}
+		k := rng.Float64()
+		c := CoordF{a.X + k*(b.X-a.X), a.Z + k*(b.Z-a.Z)}
+		if got, want := OrientT(a, b, c), ratCross(ratSub(b, a), ratSub(c, a)).Sign(); got != want {
+			t.Fatalf("OrientT(%v, %v, %v) = %d, want %d", a, b, c, got, want)
+		}
+	}
+	a, b, c := CoordF{0.1, 0.3}, CoordF{1.7, 2.9}, CoordF{0.9, 1.6}
+	a64, b64, c64 := Coord64{math.MinInt64, 1}, Coord64{math.MaxInt64, -1}, Coord64{0, 0}
+	if n := testing.AllocsPerRun(100, func() { OrientT(a, b, c); OrientT(a64, b64, c64) }); n != 0 {
+		t.Fatalf("OrientT allocates %v times per run", n)
+	}
+}
+
+func TestSegmentIntersectionT(t *testing.T) {
+	tests := []struct {
+		name           string
+		p0, p1, q0, q1 Coord64
+		ok             bool
+		x, z           *big.Rat
+	}{
+		{"crossing", Coord64{0, 0}, Coord64{3, 3}, Coord64{0, 3}, Coord64{3, 0}, true, big.NewRat(3, 2), big.NewRat(3, 2)},
+		{"touching end", Coord64{0, 0}, Coord64{1, 0}, Coord64{1, 0}, Coord64{3, 0}, true, big.NewRat(1, 1), big.NewRat(0, 1)},
+		{"collinear apart", Coord64{0, 0}, Coord64{1, 0}, Coord64{2, 0}, Coord64{3, 0}, false, nil, nil},
+		{"collinear overlap", Coord64{0, 0}, Coord64{2, 0}, Coord64{1, 0}, Coord64{3, 0}, false, nil, nil},
+		{"beyond int32", Coord64{0, 0}, Coord64{1 << 40, 1 << 40}, Coord64{0, 1 << 40}, Coord64{1 << 40, 0}, true, big.NewRat(1<<39, 1), big.NewRat(1<<39, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := SegmentIntersectionT(tt.p0, tt.p1, tt.q0, tt.q1)
+			if ok != tt.ok || ok && (r.X.Cmp(tt.x) != 0 || r.Z.Cmp(tt.z) != 0) {
+				t.Fatalf("SegmentIntersectionT() = %v, %v, want (%v, %v), %v", r, ok, tt.x, tt.z, tt.ok)
+			}
+		})
+	}
+	// float64 交点可以精确表示为 float64 时转换无损
+	r, ok := SegmentIntersectionT(CoordF{0, 0}, CoordF{1, 1}, CoordF{0, 1}, CoordF{1, 0})
+	if p, err := ConvertRatCoord[float64](r); !ok || err != nil || p != (CoordF{0.5, 0.5}) {
+		t.Fatalf("ConvertRatCoord[float64]() = %v, %v, want (0.5, 0.5)", p, err)
+	}
+}
+
+// TestGenericMatchesInt32 校验 int32 坐标经 ToCoordT 转换后，泛型版本与原有 int32 版本的判定结果一致。
+func TestGenericMatchesInt32(t *testing.T) {
+	rng := rand.New(rand.NewPCG(41, 0))
+	c := func() Coord { return Coord{rng.Int32N(2001) - 1000, rng.Int32N(2001) - 1000} }
+	for range 3000 {
+		p0, p1, q0, q1 := c(), c(), c(), c()
+		if got, want := OrientT(ToCoordT[int64](p0), ToCoordT[int64](p1), ToCoordT[int64](q0)), Orient2D(p0, p1, q0); got != want {
+			t.Fatalf("OrientT(%v, %v, %v) = %d, want %d", p0, p1, q0, got, want)
+		}
+		if got, want := IsSegmentCrossT(ToCoordT[float64](p0), ToCoordT[float64](p1), ToCoordT[float64](q0), ToCoordT[float64](q1)), isSegmentCross(p0, p1, q0, q1); got != want {
+			t.Fatalf("IsSegmentCrossT(%v, %v, %v, %v) = %v, want %v", p0, p1, q0, q1, got, want)
+		}
+		r, ok := SegmentIntersectionT(ToCoordT[int32](p0), ToCoordT[int32](p1), ToCoordT[int32](q0), ToCoordT[int32](q1))
+		want, wantOK := SegmentIntersection(p0, p1, q0, q1)
+		if ok != wantOK || ok && (r.X.Cmp(want.X) != 0 || r.Z.Cmp(want.Z) != 0) {
+			t.Fatalf("SegmentIntersectionT(%v, %v, %v, %v) = %v, %v, want %v, %v", p0, p1, q0, q1, r, ok, want, wantOK)
+		}
+		rect := NewRectangle(rng.Int32N(200)-100, rng.Int32N(200)-100, rng.Int32N(200), rng.Int32N(200))
+		if got, want := ToRectangleT[int64](rect).IsCoordInside(ToCoordT[int64](p0)), rect.IsCoordInside(p0); got != want {
+			t.Fatalf("%v IsCoordInside(%v) = %v, want %v", rect, p0, got, want)
+		}
+	}
+}
+
+func TestCircleTIsInterSegment(t *testing.T) {
+	circle := CircleF{Center: CoordF{0, 0}, Radius: 1}
+	tests := []struct {
+		name string
+		s    SegmentF
+		want bool
+	}{
+		{"inside circle", SegmentF{CoordF{-0.1, 0}, CoordF{0.1, 0}}, true},
+		{"tangent", SegmentF{CoordF{-5, 1}, CoordF{5, 1}}, true},
+		{"just outside", SegmentF{CoordF{-5, 1.01}, CoordF{5, 1.01}}, false},
+		{"pointing away", SegmentF{CoordF{2, 0}, CoordF{5, 0}}, false},
+		{"end on circle", SegmentF{CoordF{1, 0}, CoordF{5, 0}}, true},
+		{"degenerate inside", SegmentF{CoordF{0.5, 0.5}, CoordF{0.5, 0.5}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := circle.IsInterSegment(tt.s); got != tt.want {
+				t.Fatalf("IsInterSegment(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRectangleT(t *testing.T) {
+	rect := Rectangle64{CoordT: Coord64{0, 0}, Width: 10, Height: 10}
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"point on edge", rect.IsCoordInside(Coord64{10, 0}), true},
+		{"point outside", rect.IsCoordInside(Coord64{11, 0}), false},
+		{"touching corner", rect.IsInterRect(Rectangle64{CoordT: Coord64{10, 10}, Width: 1, Height: 1}), true},
+		{"apart", rect.IsInterRect(Rectangle64{CoordT: Coord64{11, 0}, Width: 1, Height: 1}), false},
+		{"beyond int32", Rectangle64{CoordT: Coord64{1 << 40, 0}, Width: 1 << 40, Height: 1}.IsCoordInside(Coord64{3 << 39, 1}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() (float64, error)
+		want float64
+		err  error
+	}{
+		{"int32 to int32", func() (float64, error) { v, err := ConvertNumber[int32](int32(7)); return float64(v), err }, 7, nil},
+		{"int64 to int32 out of range", func() (float64, error) { v, err := ConvertNumber[int32](int64(1 << 40)); return float64(v), err }, math.MaxInt32, ErrOutOfRange},
+		{"int64 to float64 beyond 2^53", func() (float64, error) { return ConvertNumber[float64](int64(1<<53 + 1)) }, 1 << 53, ErrPrecisionLoss},
+		{"int64 max to float64", func() (float64, error) { return ConvertNumber[float64](int64(math.MaxInt64)) }, 0x1p63, ErrPrecisionLoss},
+		{"float64 fraction to int64", func() (float64, error) { v, err := ConvertNumber[int64](2.5); return float64(v), err }, 2, ErrPrecisionLoss},
+		{"float64 to int64 out of range", func() (float64, error) { v, err := ConvertNumber[int64](1e19); return float64(v), err }, math.MaxInt64, ErrOutOfRange},
+		{"float64 integer to int32", func() (float64, error) { v, err := ConvertNumber[int32](-12.0); return float64(v), err }, -12, nil},
+		{"float64 NaN to int32", func() (float64, error) { v, err := ConvertNumber[int32](math.NaN()); return float64(v), err }, 0, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.got()
+			if got != tt.want || !errors.Is(err, tt.err) || (err == nil) != (tt.err == nil) {
+				t.Fatalf("ConvertNumber() = %v, %v, want %v, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestConvertRatCoord(t *testing.T) {
+	half := RatCoord{X: big.NewRat(3, 2), Z: big.NewRat(-5, 2)}
+	tests := []struct {
+		name string
+		mode RoundingMode
+		r    RatCoord
+		want Coord64
+		err  error
+	}{
+		{"truncate", RoundTruncate, half, Coord64{1, -2}, ErrPrecisionLoss},
+		{"half even", RoundHalfEven, half, Coord64{2, -2}, ErrPrecisionLoss},
+		{"floor", RoundFloor, half, Coord64{1, -3}, ErrPrecisionLoss},
+		{"exact", RoundTruncate, RatCoord{X: big.NewRat(4, 2), Z: big.NewRat(-6, 3)}, Coord64{2, -2}, nil},
+		{"out of range", RoundTruncate, RatCoord{X: new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 70), big.NewInt(1)), Z: big.NewRat(0, 1)}, Coord64{math.MaxInt64, 0}, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, tt.mode, nil)
+			got, err := ConvertRatCoord[int64](tt.r)
+			if got != tt.want || !errors.Is(err, tt.err) || (err == nil) != (tt.err == nil) {
+				t.Fatalf("ConvertRatCoord() = %v, %v, want %v, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestGenericRoundTrip(t *testing.T) {
+	seg := NewSegment(Coord{1, 2}, Coord{3, 4})
+	if got, err := ToSegmentT[float64](seg).Segment(); err != nil || got != seg {
+		t.Fatalf("Segment() = %v, %v, want %v", got, err, seg)
+	}
+	rect := NewRectangle(1, 2, 3, 4)
+	if got, err := ToRectangleT[int64](rect).Rectangle(); err != nil || got != rect {
+		t.Fatalf("Rectangle() = %v, %v, want %v", got, err, rect)
+	}
+	v := ToVectorT[float64](Vector{3, 4})
+	if got, err := v.Vector(); err != nil || got != (Vector{3, 4}) || v.Length() != 5 {
+		t.Fatalf("Vector() = %v, %v, length %v", got, err, v.Length())
+	}
+	circle := NewCirCle(Coord{-7, 9}, 11)
+	if got, err := ToCircleT[int64](circle).Circle(); err != nil || got != circle {
+		t.Fatalf("Circle() = %v, %v, want %v", got, err, circle)
+	}
+	// 圆心有小数且半径越界时两种错误都会返回
+	if _, err := (CircleF{Center: CoordF{0.5, 0}, Radius: 1e12}).Circle(); !errors.Is(err, ErrPrecisionLoss) || !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("Circle() = %v, want both %v and %v", err, ErrPrecisionLoss, ErrOutOfRange)
+	}
+}
@@ -0,0 +1,659 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// 本文件提供 GeoJSON（RFC 7946）格式的导入导出，便于在 QGIS 等 GIS 工具中绘制与查看区域。
+// 坐标 (X, Z) 映射为 GeoJSON 的 [x, y] 位置，多边形外环按逆时针排列并首尾闭合。
+// 导入时位置可以是浮点数（GIS 工具导出的坐标通常带小数），按包级取整方式 Rounding 取整，
+// 超出 int32 范围时返回 ErrOutOfRange；第三个分量（高度）被忽略。
+// 导入时既接受几何对象本身，也接受包裹该几何对象的 Feature。
+
+// ErrInvalidGeoJSON 表示 GeoJSON 数据格式错误或与目标类型不符
+var ErrInvalidGeoJSON = errors.New("geo: invalid GeoJSON")
+
+// 导航网格 Feature 的 kind 属性取值。
+const (
+	geoJSONKindVertex   = "vertex"
+	geoJSONKindEdge     = "edge"
+	geoJSONKindTriangle = "triangle"
+	geoJSONKindConvex   = "convex"
+)
+
+// geoJSONObject 为 GeoJSON 对象的通用结构，可表示几何对象、Feature 与 FeatureCollection。
+type geoJSONObject struct {
+	Type        string             `json:"type"`
+	Coordinates json.RawMessage    `json:"coordinates,omitempty"`
+	Geometry    *geoJSONObject     `json:"geometry,omitempty"`
+	Properties  *geoJSONProperties `json:"properties,omitempty"`
+	Features    []*geoJSONObject   `json:"features,omitempty"`
+}
+
+// geoJSONProperties 为导航网格 Feature 携带的属性，用于精确重建三角形与凸多边形的邻接关系。
+// 其余属性（如设计师添加的标注）在导入时被忽略。
+type geoJSONProperties struct {
+	Kind      string  `json:"kind,omitempty"`      // vertex、edge、triangle 或 convex
+	Index     *int32  `json:"index,omitempty"`     // 顶点、边、三角形或凸多边形的序号
+	Vertices  []int32 `json:"vertices,omitempty"`  // 各顶点的序号，与几何坐标一一对应
+	EdgeIDs   []int32 `json:"edgeIDs,omitempty"`   // 第 k 条边连接第 k 与第 k+1 个顶点
+	WtCoord   *Coord  `json:"wtCoord,omitempty"`   // 边或凸多边形的加权坐标
+	Adjacency *bool   `json:"adjacency,omitempty"` // 边是否为可通行的邻接边
+	Triangles []int32 `json:"triangles,omitempty"` // 凸多边形合并前的三角形序号
+}
+
+// MarshalGeoJSON 将坐标点导出为 GeoJSON Point。
+func (c Coord) MarshalGeoJSON() ([]byte, error) {
+	return json.Marshal(newGeoJSONGeometry("Point", geoJSONPosition(c)))
+}
+
+// UnmarshalGeoJSON 从 GeoJSON Point（或包裹它的 Feature）导入坐标点。
+func (c *Coord) UnmarshalGeoJSON(data []byte) error {
+	geom, _, err := decodeGeoJSONGeometry(data, "Point")
+	if err != nil {
+		return err
+	}
+	p, err := decodeGeoJSONPoint(geom)
+	if err != nil {
+		return err
+	}
+	*c = p
+	return nil
+}
+
+// MarshalGeoJSON 将线段导出为包含两个点的 GeoJSON LineString。
+func (s *Segment) MarshalGeoJSON() ([]byte, error) {
+	return MarshalLineStringGeoJSON([]Coord{s.A, s.B})
+}
+
+// UnmarshalGeoJSON 从恰好包含两个点的 GeoJSON LineString 导入线段。
+func (s *Segment) UnmarshalGeoJSON(data []byte) error {
+	coords, err := UnmarshalLineStringGeoJSON(data)
+	if err != nil {
+		return err
+	}
+	if len(coords) != 2 {
+		return fmt.Errorf("%w: segment needs 2 positions, got %d", ErrInvalidGeoJSON, len(coords))
+	}
+	*s = NewSegment(coords[0], coords[1])
+	return nil
+}
+
+// MarshalLineStringGeoJSON 将折线（如寻路路径、巡逻路线）导出为 GeoJSON LineString。
+func MarshalLineStringGeoJSON(coords []Coord) ([]byte, error) {
+	if len(coords) < 2 {
+		return nil, fmt.Errorf("%w: line string needs at least 2 positions, got %d", ErrInvalidGeoJSON, len(coords))
+	}
+	return json.Marshal(newGeoJSONGeometry("LineString", geoJSONPositions(coords)))
+}
+
+// UnmarshalLineStringGeoJSON 从 GeoJSON LineString（或包裹它的 Feature）导入折线。
+func UnmarshalLineStringGeoJSON(data []byte) ([]Coord, error) {
+	geom, _, err := decodeGeoJSONGeometry(data, "LineString")
+	if err != nil {
+		return nil, err
+	}
+	coords, err := decodeGeoJSONLineString(geom)
+	if err != nil {
+		return nil, err
+	}
+	if len(coords) < 2 {
+		return nil, fmt.Errorf("%w: line string needs at least 2 positions, got %d", ErrInvalidGeoJSON, len(coords))
+	}
+	return coords, nil
+}
+
+// MarshalGeoJSON 将矩形导出为 GeoJSON Polygon，外环为逆时针的四个角点（左下起）。
+func (rec *Rectangle) MarshalGeoJSON() ([]byte, error) {
+	corners := rec.GetVerticeCoords()
+	return json.Marshal(newGeoJSONPolygon(corners[:]))
+}
+
+// UnmarshalGeoJSON 从 GeoJSON Polygon 导入矩形，外环须恰好由轴对齐矩形的四个角点组成（方向不限）。
+func (rec *Rectangle) UnmarshalGeoJSON(data []byte) error {
+	geom, _, err := decodeGeoJSONGeometry(data, "Polygon")
+	if err != nil {
+		return err
+	}
+	ring, err := decodeGeoJSONPolygon(geom)
+	if err != nil {
+		return err
+	}
+	if len(ring) != 4 {
+		return fmt.Errorf("%w: rectangle needs 4 corners, got %d", ErrInvalidGeoJSON, len(ring))
+	}
+	minX, minZ := min(ring[0].X, ring[1].X, ring[2].X, ring[3].X), min(ring[0].Z, ring[1].Z, ring[2].Z, ring[3].Z)
+	maxX, maxZ := max(ring[0].X, ring[1].X, ring[2].X, ring[3].X), max(ring[0].Z, ring[1].Z, ring[2].Z, ring[3].Z)
+	r := NewRectangle(minX, minZ, maxX-minX, maxZ-minZ)
+	corners := r.GetVerticeCoords()
+	for _, c := range corners {
+		if !slices.Contains(ring, c) {
+			return fmt.Errorf("%w: polygon is not an axis-aligned rectangle", ErrInvalidGeoJSON)
+		}
+	}
+	*rec = r
+	return nil
+}
+
+// MarshalGeoJSON 将三角形导出为 GeoJSON Feature，几何为 Polygon，属性携带 Index、顶点序号与 EdgeIDs。
+func (t *Triangle) MarshalGeoJSON() ([]byte, error) {
+	return json.Marshal(triangleFeature(t))
+}
+
+// UnmarshalGeoJSON 从 GeoJSON Feature 或 Polygon 导入三角形，外环须恰好有 3 个顶点。
+// 缺少属性时 Index 为 0、顶点序号按外环顺序从 0 开始、EdgeIDs 为空；导入后重新计算 Center。
+func (t *Triangle) UnmarshalGeoJSON(data []byte) error {
+	geom, props, err := decodeGeoJSONGeometry(data, "Polygon")
+	if err != nil {
+		return err
+	}
+	tri, err := parseTriangleFeature(geom, props)
+	if err != nil {
+		return err
+	}
+	*t = *tri
+	return nil
+}
+
+// MarshalGeoJSON 将凸多边形导出为 GeoJSON Feature，几何为 Polygon，
+// 属性携带 Index、顶点序号、EdgeIDs、WtCoord 与 MergeTriangles 中各三角形的序号。
+func (c *Convex) MarshalGeoJSON() ([]byte, error) {
+	return json.Marshal(convexFeature(c))
+}
+
+// UnmarshalGeoJSON 从 GeoJSON Feature 或 Polygon 导入凸多边形。
+// 单独导入时无法还原 MergeTriangles（其中只记录了三角形序号），需要时使用 UnmarshalMeshGeoJSON。
+func (c *Convex) UnmarshalGeoJSON(data []byte) error {
+	geom, props, err := decodeGeoJSONGeometry(data, "Polygon")
+	if err != nil {
+		return err
+	}
+	convex, _, err := parseConvexFeature(geom, props)
+	if err != nil {
+		return err
+	}
+	*c = *convex
+	return nil
+}
+
+// MarshalGeoJSON 将导航网格导出为 GeoJSON FeatureCollection，见 MarshalMeshGeoJSON。
+func (m *NavMesh) MarshalGeoJSON() ([]byte, error) {
+	return MarshalMeshGeoJSON(m, nil)
+}
+
+// UnmarshalGeoJSON 从 GeoJSON FeatureCollection 导入导航网格，忽略其中的凸多边形，见 UnmarshalMeshGeoJSON。
+func (m *NavMesh) UnmarshalGeoJSON(data []byte) error {
+	mesh, _, err := UnmarshalMeshGeoJSON(data)
+	if err != nil {
+		return err
+	}
+	*m = *mesh
+	return nil
+}
+
+// MarshalMeshGeoJSON 将导航网格及其合并得到的凸多边形（可为 nil）导出为一个 GeoJSON FeatureCollection。
+// 依次输出全部顶点（Point）、边（LineString）、三角形与凸多边形（Polygon），
+// 每个 Feature 的 kind 属性标明类型，并携带 Index、顶点序号、EdgeIDs、WtCoord 等属性，
+// 使 UnmarshalMeshGeoJSON 能够精确重建三角形、边与凸多边形之间的邻接关系。
+// 边上漏斗算法的拐点缓存（Edge.Inflects）不导出。
+func MarshalMeshGeoJSON(m *NavMesh, convexes []*Convex) ([]byte, error) {
+	fc := &geoJSONObject{Type: "FeatureCollection", Features: []*geoJSONObject{}}
+	for _, v := range m.Vertices {
+		fc.Features = append(fc.Features, newGeoJSONFeature(newGeoJSONGeometry("Point", geoJSONPosition(v.Coord)), &geoJSONProperties{
+			Kind:  geoJSONKindVertex,
+			Index: &v.Index,
+		}))
+	}
+	for i, e := range m.Edges {
+		id, adjacency, wt := int32(i), e.IsAdjacency, e.WtCoord
+		fc.Features = append(fc.Features, newGeoJSONFeature(newGeoJSONGeometry("LineString", geoJSONPositions([]Coord{e.Vertices[0].Coord, e.Vertices[1].Coord})), &geoJSONProperties{
+			Kind:      geoJSONKindEdge,
+			Index:     &id,
+			Vertices:  []int32{e.Vertices[0].Index, e.Vertices[1].Index},
+			WtCoord:   &wt,
+			Adjacency: &adjacency,
+		}))
+	}
+	for _, t := range m.Triangles {
+		fc.Features = append(fc.Features, triangleFeature(t))
+	}
+	for _, c := range convexes {
+		fc.Features = append(fc.Features, convexFeature(c))
+	}
+	return json.Marshal(fc)
+}
+
+// UnmarshalMeshGeoJSON 从 MarshalMeshGeoJSON 导出的 FeatureCollection 重建导航网格与凸多边形。
+// 顶点、边、三角形与凸多边形的序号均与导出时一致：
+// 缺少顶点 Feature 时由三角形的顶点属性还原；缺少边 Feature 时由三角形的 EdgeIDs 还原，
+// 此时被两个三角形共享的边视为邻接边。凸多边形的 MergeTriangles 按序号指向重建后的三角形。
+// 没有 kind 属性的 Feature 被忽略；序号不连续、重复或引用不存在的对象，三角形或凸多边形的顶点坐标与同序号的顶点不一致、
+// 环不是逆时针的非退化凸多边形，或 EdgeIDs 与边表中连接相邻顶点的边不符时，返回 ErrInvalidGeoJSON。
+func UnmarshalMeshGeoJSON(data []byte) (*NavMesh, []*Convex, error) {
+	var fc geoJSONObject
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, nil, fmt.Errorf("%w: want FeatureCollection, got %q", ErrInvalidGeoJSON, fc.Type)
+	}
+
+	vertices := make(map[int32]Coord)
+	edges := make(map[int32]*Edge)
+	triangles := make(map[int32]*Triangle)
+	var convexes []*Convex
+	var convexTris [][]int32
+	for i, f := range fc.Features {
+		if f == nil || f.Type != "Feature" || f.Geometry == nil || f.Properties == nil || f.Properties.Kind == "" {
+			continue
+		}
+		props := f.Properties
+		if props.Index == nil {
+			return nil, nil, fmt.Errorf("%w: feature %d (%s) has no index", ErrInvalidGeoJSON, i, props.Kind)
+		}
+		var err error
+		switch props.Kind {
+		case geoJSONKindVertex:
+			var c Coord
+			if c, err = decodeGeoJSONPoint(f.Geometry); err == nil {
+				err = putIndexed(vertices, *props.Index, c)
+			}
+		case geoJSONKindEdge:
+			if len(props.Vertices) != 2 {
+				err = fmt.Errorf("%w: edge needs 2 vertex indices", ErrInvalidGeoJSON)
+				break
+			}
+			e := &Edge{IsAdjacency: props.Adjacency != nil && *props.Adjacency}
+			if props.WtCoord != nil {
+				e.WtCoord = *props.WtCoord
+			}
+			// 顶点坐标在顶点表完整后再填入
+			e.Vertices[0].Index, e.Vertices[1].Index = props.Vertices[0], props.Vertices[1]
+			err = putIndexed(edges, *props.Index, e)
+		case geoJSONKindTriangle:
+			var t *Triangle
+			if t, err = parseTriangleFeature(f.Geometry, props); err == nil {
+				err = putIndexed(triangles, t.Index, t)
+			}
+		case geoJSONKindConvex:
+			var c *Convex
+			var tris []int32
+			if c, tris, err = parseConvexFeature(f.Geometry, props); err == nil {
+				convexes = append(convexes, c)
+				convexTris = append(convexTris, tris)
+			}
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+	}
+
+	// 三角形的顶点同样记录了坐标，补全缺失的顶点；同一序号的坐标必须一致
+	for _, t := range triangles {
+		for _, v := range t.Vertices {
+			c, ok := vertices[v.Index]
+			if !ok {
+				vertices[v.Index] = v.Coord
+			} else if c != v.Coord {
+				return nil, nil, fmt.Errorf("%w: triangle %d vertex %d at %v, want %v", ErrInvalidGeoJSON, t.Index, v.Index, v.Coord, c)
+			}
+		}
+	}
+	m := &NavMesh{edgeIndex: make(map[int64]int32, len(edges))}
+	coords, err := denseIndexed(vertices, "vertex")
+	if err != nil {
+		return nil, nil, err
+	}
+	m.Vertices = make([]Vertice, len(coords))
+	for i, c := range coords {
+		m.Vertices[i] = Vertice{Index: int32(i), Coord: c}
+	}
+	if m.Triangles, err = denseIndexed(triangles, "triangle"); err != nil {
+		return nil, nil, err
+	}
+
+	// 补全缺失的边，再按三角形顺序还原共享关系
+	derived := make(map[int32]bool)
+	for _, t := range m.Triangles {
+		if len(t.EdgeIDs) != 3 {
+			return nil, nil, fmt.Errorf("%w: triangle %d needs 3 edge ids", ErrInvalidGeoJSON, t.Index)
+		}
+		for k, id := range t.EdgeIDs {
+			if _, ok := edges[id]; !ok {
+				edges[id] = &Edge{Vertices: [2]Vertice{t.Vertices[k], t.Vertices[(k+1)%3]}}
+				derived[id] = true
+			}
+		}
+	}
+	if m.Edges, err = denseIndexed(edges, "edge"); err != nil {
+		return nil, nil, err
+	}
+	for id, e := range m.Edges {
+		for k := range e.Vertices {
+			idx := e.Vertices[k].Index
+			if idx < 0 || int(idx) >= len(m.Vertices) {
+				return nil, nil, fmt.Errorf("%w: edge %d references vertex %d", ErrInvalidGeoJSON, id, idx)
+			}
+			e.Vertices[k] = m.Vertices[idx]
+		}
+		m.edgeIndex[e.GenKey()] = int32(id)
+	}
+	for _, t := range m.Triangles {
+		if err := validateMeshPolygon(m, "triangle", t.Index, t.Vertices, t.EdgeIDs); err != nil {
+			return nil, nil, err
+		}
+		for _, id := range t.EdgeIDs {
+			e := m.Edges[id]
+			e.AdjacenctTriangles = append(e.AdjacenctTriangles, t)
+			if derived[id] {
+				e.IsAdjacency = len(e.AdjacenctTriangles) == 2
+			}
+		}
+	}
+
+	for i, c := range convexes {
+		if err := validateMeshPolygon(m, "convex", c.Index, c.Vertices, c.EdgeIDs); err != nil {
+			return nil, nil, err
+		}
+		if convexTris[i] == nil {
+			continue
+		}
+		c.MergeTriangles = make([]*Triangle, len(convexTris[i]))
+		for j, ti := range convexTris[i] {
+			if ti < 0 || int(ti) >= len(m.Triangles) {
+				return nil, nil, fmt.Errorf("%w: convex %d references triangle %d", ErrInvalidGeoJSON, c.Index, ti)
+			}
+			c.MergeTriangles[j] = m.Triangles[ti]
+		}
+	}
+	return m, convexes, nil
+}
+
+// validateMeshPolygon 校验导入的三角形或凸多边形与重建后的网格一致：
+// 顶点坐标与同序号的顶点相同，环为逆时针且不退化、不含凹顶点，
+// EdgeIDs（非空时）与顶点一一对应，第 k 条边连接 vertices[k] 与 vertices[(k+1)%n]。
+func validateMeshPolygon(m *NavMesh, kind string, index int32, vertices []Vertice, edgeIDs []int32) error {
+	n := len(vertices)
+	coords := make([]Coord, n)
+	for i, v := range vertices {
+		if v.Index < 0 || int(v.Index) >= len(m.Vertices) {
+			return fmt.Errorf("%w: %s %d references vertex %d", ErrInvalidGeoJSON, kind, index, v.Index)
+		}
+		if want := m.Vertices[v.Index].Coord; v.Coord != want {
+			return fmt.Errorf("%w: %s %d vertex %d at %v, want %v", ErrInvalidGeoJSON, kind, index, v.Index, v.Coord, want)
+		}
+		coords[i] = v.Coord
+	}
+	if n < 3 || signedArea2(coords) <= 0 {
+		return fmt.Errorf("%w: %s %d is degenerate or not counter-clockwise", ErrInvalidGeoJSON, kind, index)
+	}
+	for i := range n {
+		if Orient2D(coords[i], coords[(i+1)%n], coords[(i+2)%n]) < 0 {
+			return fmt.Errorf("%w: %s %d is not convex at vertex %d", ErrInvalidGeoJSON, kind, index, vertices[(i+1)%n].Index)
+		}
+	}
+	if edgeIDs == nil {
+		return nil
+	}
+	if len(edgeIDs) != n {
+		return fmt.Errorf("%w: %s %d has %d edge ids for %d vertices", ErrInvalidGeoJSON, kind, index, len(edgeIDs), n)
+	}
+	for k, id := range edgeIDs {
+		a, b := vertices[k].Index, vertices[(k+1)%n].Index
+		if e := m.GetEdge(id); e == nil || e.GenKey() != GenEdgeKey(a, b) {
+			return fmt.Errorf("%w: %s %d edge id %d does not connect vertices %d-%d", ErrInvalidGeoJSON, kind, index, id, a, b)
+		}
+	}
+	return nil
+}
+
+// triangleFeature 生成三角形的 Feature。
+func triangleFeature(t *Triangle) *geoJSONObject {
+	coords, indices := splitVertices(t.Vertices)
+	index := t.Index
+	return newGeoJSONFeature(newGeoJSONPolygon(coords), &geoJSONProperties{
+		Kind:     geoJSONKindTriangle,
+		Index:    &index,
+		Vertices: indices,
+		EdgeIDs:  t.EdgeIDs,
+	})
+}
+
+// convexFeature 生成凸多边形的 Feature。
+func convexFeature(c *Convex) *geoJSONObject {
+	coords, indices := splitVertices(c.Vertices)
+	index, wt := c.Index, c.WtCoord
+	props := &geoJSONProperties{
+		Kind:     geoJSONKindConvex,
+		Index:    &index,
+		Vertices: indices,
+		EdgeIDs:  c.EdgeIDs,
+		WtCoord:  &wt,
+	}
+	for _, t := range c.MergeTriangles {
+		props.Triangles = append(props.Triangles, t.Index)
+	}
+	return newGeoJSONFeature(newGeoJSONPolygon(coords), props)
+}
+
+// parseTriangleFeature 由 Polygon 几何与属性（可为 nil）还原三角形。
+func parseTriangleFeature(geom *geoJSONObject, props *geoJSONProperties) (*Triangle, error) {
+	vertices, err := parseFeatureVertices(geom, props)
+	if err != nil {
+		return nil, err
+	}
+	if len(vertices) != 3 {
+		return nil, fmt.Errorf("%w: triangle needs 3 vertices, got %d", ErrInvalidGeoJSON, len(vertices))
+	}
+	t := &Triangle{Vertices: vertices}
+	if props != nil {
+		if props.Index != nil {
+			t.Index = *props.Index
+		}
+		t.EdgeIDs = props.EdgeIDs
+	}
+	t.CalCenter()
+	return t, nil
+}
+
+// parseConvexFeature 由 Polygon 几何与属性（可为 nil）还原凸多边形，并返回 MergeTriangles 的三角形序号。
+func parseConvexFeature(geom *geoJSONObject, props *geoJSONProperties) (*Convex, []int32, error) {
+	vertices, err := parseFeatureVertices(geom, props)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &Convex{Vertices: vertices}
+	if props == nil {
+		return c, nil, nil
+	}
+	if props.Index != nil {
+		c.Index = *props.Index
+	}
+	if props.WtCoord != nil {
+		c.WtCoord = *props.WtCoord
+	}
+	c.EdgeIDs = props.EdgeIDs
+	return c, props.Triangles, nil
+}
+
+// parseFeatureVertices 解析 Polygon 外环，并按属性中的顶点序号组装顶点；缺少序号时按外环顺序从 0 编号。
+func parseFeatureVertices(geom *geoJSONObject, props *geoJSONProperties) ([]Vertice, error) {
+	ring, err := decodeGeoJSONPolygon(geom)
+	if err != nil {
+		return nil, err
+	}
+	if props != nil && props.Vertices != nil && len(props.Vertices) != len(ring) {
+		return nil, fmt.Errorf("%w: %d vertex indices for %d positions", ErrInvalidGeoJSON, len(props.Vertices), len(ring))
+	}
+	vertices := make([]Vertice, len(ring))
+	for i, c := range ring {
+		vertices[i] = Vertice{Index: int32(i), Coord: c}
+		if props != nil && props.Vertices != nil {
+			vertices[i].Index = props.Vertices[i]
+		}
+	}
+	return vertices, nil
+}
+
+// splitVertices 拆分顶点列表为坐标与序号。
+func splitVertices(vertices []Vertice) ([]Coord, []int32) {
+	coords := make([]Coord, len(vertices))
+	indices := make([]int32, len(vertices))
+	for i, v := range vertices {
+		coords[i], indices[i] = v.Coord, v.Index
+	}
+	return coords, indices
+}
+
+// putIndexed 以序号存入对象，序号为负或重复时返回错误。
+func putIndexed[T any](m map[int32]T, index int32, v T) error {
+	if _, ok := m[index]; ok || index < 0 {
+		return fmt.Errorf("%w: duplicate or negative index %d", ErrInvalidGeoJSON, index)
+	}
+	m[index] = v
+	return nil
+}
+
+// denseIndexed 将以序号为键的对象转换为以序号为下标的切片，序号须从 0 开始连续。
+func denseIndexed[T any](m map[int32]T, kind string) ([]T, error) {
+	ret := make([]T, len(m))
+	for i, v := range m {
+		if i < 0 || int(i) >= len(m) {
+			return nil, fmt.Errorf("%w: %s indices are not contiguous from 0 (found %d of %d)", ErrInvalidGeoJSON, kind, i, len(m))
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+// newGeoJSONGeometry 创建几何对象；坐标均为整数数组，序列化不会失败。
+func newGeoJSONGeometry(typ string, coordinates any) *geoJSONObject {
+	raw, _ := json.Marshal(coordinates)
+	return &geoJSONObject{Type: typ, Coordinates: raw}
+}
+
+// newGeoJSONPolygon 创建只有外环的 Polygon，外环首尾闭合。
+func newGeoJSONPolygon(ring []Coord) *geoJSONObject {
+	positions := geoJSONPositions(ring)
+	if len(positions) > 0 {
+		positions = append(positions, positions[0])
+	}
+	return newGeoJSONGeometry("Polygon", [][][2]int32{positions})
+}
+
+// newGeoJSONFeature 创建 Feature。
+func newGeoJSONFeature(geom *geoJSONObject, props *geoJSONProperties) *geoJSONObject {
+	return &geoJSONObject{Type: "Feature", Geometry: geom, Properties: props}
+}
+
+// geoJSONPosition 将坐标转换为 GeoJSON 位置。
+func geoJSONPosition(c Coord) [2]int32 {
+	return [2]int32{c.X, c.Z}
+}
+
+// geoJSONPositions 将坐标列表转换为 GeoJSON 位置列表。
+func geoJSONPositions(coords []Coord) [][2]int32 {
+	positions := make([][2]int32, len(coords))
+	for i, c := range coords {
+		positions[i] = geoJSONPosition(c)
+	}
+	return positions
+}
+
+// decodeGeoJSONGeometry 解析几何对象或 Feature，返回类型为 want 的几何对象及 Feature 的属性（几何对象本身时为 nil）。
+func decodeGeoJSONGeometry(data []byte, want string) (*geoJSONObject, *geoJSONProperties, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+	geom, props := &obj, (*geoJSONProperties)(nil)
+	if obj.Type == "Feature" {
+		geom, props = obj.Geometry, obj.Properties
+	}
+	if geom == nil || geom.Type != want {
+		got := "null"
+		if geom != nil {
+			got = geom.Type
+		}
+		return nil, nil, fmt.Errorf("%w: want %s geometry, got %s", ErrInvalidGeoJSON, want, got)
+	}
+	return geom, props, nil
+}
+
+// decodeGeoJSONPoint 解析 Point 的坐标。
+func decodeGeoJSONPoint(geom *geoJSONObject) (Coord, error) {
+	if geom.Type != "Point" {
+		return Coord{}, fmt.Errorf("%w: want Point geometry, got %s", ErrInvalidGeoJSON, geom.Type)
+	}
+	var position []float64
+	if err := json.Unmarshal(geom.Coordinates, &position); err != nil {
+		return Coord{}, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+	return geoJSONCoord(position)
+}
+
+// decodeGeoJSONLineString 解析 LineString 的坐标列表。
+func decodeGeoJSONLineString(geom *geoJSONObject) ([]Coord, error) {
+	if geom.Type != "LineString" {
+		return nil, fmt.Errorf("%w: want LineString geometry, got %s", ErrInvalidGeoJSON, geom.Type)
+	}
+	var positions [][]float64
+	if err := json.Unmarshal(geom.Coordinates, &positions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+	return geoJSONCoords(positions)
+}
+
+// decodeGeoJSONPolygon 解析只有外环的 Polygon，返回去掉闭合点的外环坐标。
+func decodeGeoJSONPolygon(geom *geoJSONObject) ([]Coord, error) {
+	if geom.Type != "Polygon" {
+		return nil, fmt.Errorf("%w: want Polygon geometry, got %s", ErrInvalidGeoJSON, geom.Type)
+	}
+	var rings [][][]float64
+	if err := json.Unmarshal(geom.Coordinates, &rings); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+	if len(rings) != 1 {
+		return nil, fmt.Errorf("%w: polygon needs exactly 1 ring, got %d", ErrInvalidGeoJSON, len(rings))
+	}
+	ring, err := geoJSONCoords(rings[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	return ring, nil
+}
+
+// geoJSONCoords 将 GeoJSON 位置列表转换为坐标列表。
+func geoJSONCoords(positions [][]float64) ([]Coord, error) {
+	coords := make([]Coord, len(positions))
+	for i, p := range positions {
+		c, err := geoJSONCoord(p)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = c
+	}
+	return coords, nil
+}
+
+// geoJSONCoord 将 GeoJSON 位置转换为坐标，按包级取整方式 Rounding 取整。
+func geoJSONCoord(position []float64) (Coord, error) {
+	if len(position) < 2 {
+		return Coord{}, fmt.Errorf("%w: position needs at least 2 values, got %d", ErrInvalidGeoJSON, len(position))
+	}
+	x, err := Rounding.RoundChecked(position[0])
+	if err != nil {
+		return Coord{}, err
+	}
+	z, err := Rounding.RoundChecked(position[1])
+	if err != nil {
+		return Coord{}, err
+	}
+	return Coord{X: x, Z: z}, nil
+}
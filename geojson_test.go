@@ -0,0 +1,330 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCoordUnmarshalGeoJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		mode RoundingMode
+		want Coord
+		err  error
+	}{
+		{"point", `{"type":"Point","coordinates":[3,-4]}`, RoundTruncate, Coord{3, -4}, nil},
+		{"feature with height", `{"type":"Feature","geometry":{"type":"Point","coordinates":[1.6,2.2,99]},"properties":{"name":"spawn"}}`, RoundTruncate, Coord{1, 2}, nil},
+		{"half even", `{"type":"Point","coordinates":[1.6,-2.5]}`, RoundHalfEven, Coord{2, -2}, nil},
+		{"floor", `{"type":"Point","coordinates":[1.6,-2.2]}`, RoundFloor, Coord{1, -3}, nil},
+		{"out of range", `{"type":"Point","coordinates":[1e20,0]}`, RoundTruncate, Coord{}, ErrOutOfRange},
+		{"wrong geometry", `{"type":"LineString","coordinates":[[0,0],[1,1]]}`, RoundTruncate, Coord{}, ErrInvalidGeoJSON},
+		{"missing position", `{"type":"Point","coordinates":[1]}`, RoundTruncate, Coord{}, ErrInvalidGeoJSON},
+		{"malformed", `{"type":"Point",`, RoundTruncate, Coord{}, ErrInvalidGeoJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, tt.mode, nil)
+			var c Coord
+			err := c.UnmarshalGeoJSON([]byte(tt.data))
+			if !errors.Is(err, tt.err) || (err == nil) != (tt.err == nil) {
+				t.Fatalf("UnmarshalGeoJSON() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && c != tt.want {
+				t.Fatalf("UnmarshalGeoJSON() = %v, want %v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestShapeGeoJSONRoundTrip(t *testing.T) {
+	seg := NewSegment(Coord{0, 0}, Coord{5, 6})
+	rect := NewRectangle(-5, 2, 10, 20)
+	line := []Coord{{0, 0}, {1, 1}, {2, 0}}
+	tests := []struct {
+		name      string
+		marshal   func() ([]byte, error)
+		unmarshal func([]byte) (any, error)
+		want      any
+		json      string
+	}{
+		{"coord", Coord{3, -4}.MarshalGeoJSON, func(b []byte) (any, error) { var c Coord; err := c.UnmarshalGeoJSON(b); return c, err }, Coord{3, -4}, `{"type":"Point","coordinates":[3,-4]}`},
+		{"segment", seg.MarshalGeoJSON, func(b []byte) (any, error) { var s Segment; err := s.UnmarshalGeoJSON(b); return s, err }, seg, `{"type":"LineString","coordinates":[[0,0],[5,6]]}`},
+		{"line string", func() ([]byte, error) { return MarshalLineStringGeoJSON(line) }, func(b []byte) (any, error) { return UnmarshalLineStringGeoJSON(b) }, line, `{"type":"LineString","coordinates":[[0,0],[1,1],[2,0]]}`},
+		{"rectangle", rect.MarshalGeoJSON, func(b []byte) (any, error) { var r Rectangle; err := r.UnmarshalGeoJSON(b); return r, err }, rect, `{"type":"Polygon","coordinates":[[[-5,2],[5,2],[5,22],[-5,22],[-5,2]]]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.marshal()
+			if err != nil || string(b) != tt.json {
+				t.Fatalf("MarshalGeoJSON() = %s, %v, want %s", b, err, tt.json)
+			}
+			got, err := tt.unmarshal(b)
+			if err != nil {
+				t.Fatalf("UnmarshalGeoJSON(%s) error = %v", b, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("UnmarshalGeoJSON(%s) = %v, want %v", b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShapeUnmarshalGeoJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		unmarshal func([]byte) error
+		err       error
+	}{
+		{"segment from long line", `{"type":"LineString","coordinates":[[0,0],[1,1],[2,0]]}`, func(b []byte) error { var s Segment; return s.UnmarshalGeoJSON(b) }, ErrInvalidGeoJSON},
+		{"line string of one point", `{"type":"LineString","coordinates":[[0,0]]}`, func(b []byte) error { _, err := UnmarshalLineStringGeoJSON(b); return err }, ErrInvalidGeoJSON},
+		{"rectangle not axis aligned", `{"type":"Polygon","coordinates":[[[0,0],[10,0],[12,5],[0,5],[0,0]]]}`, func(b []byte) error { var r Rectangle; return r.UnmarshalGeoJSON(b) }, ErrInvalidGeoJSON},
+		{"rectangle of three corners", `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,5],[0,0]]]}`, func(b []byte) error { var r Rectangle; return r.UnmarshalGeoJSON(b) }, ErrInvalidGeoJSON},
+		{"triangle of four vertices", `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,5],[0,5],[0,0]]]}`, func(b []byte) error { var tri Triangle; return tri.UnmarshalGeoJSON(b) }, ErrInvalidGeoJSON},
+		{"rectangle out of range", `{"type":"Polygon","coordinates":[[[0,0],[1e10,0],[1e10,5],[0,5],[0,0]]]}`, func(b []byte) error { var r Rectangle; return r.UnmarshalGeoJSON(b) }, ErrOutOfRange},
+		{"mesh from point", `{"type":"Point","coordinates":[0,0]}`, func(b []byte) error { var m NavMesh; return m.UnmarshalGeoJSON(b) }, ErrInvalidGeoJSON},
+		{"mesh with gap in indices", `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":{"kind":"vertex","index":3}}]}`,
+			func(b []byte) error { _, _, err := UnmarshalMeshGeoJSON(b); return err }, ErrInvalidGeoJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.unmarshal([]byte(tt.data)); !errors.Is(err, tt.err) {
+				t.Fatalf("UnmarshalGeoJSON() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPolygonUnmarshalGeoJSON(t *testing.T) {
+	// 顺时针的矩形同样接受
+	var r Rectangle
+	if err := r.UnmarshalGeoJSON([]byte(`{"type":"Polygon","coordinates":[[[0,0],[0,5],[10,5],[10,0],[0,0]]]}`)); err != nil || r != NewRectangle(0, 0, 10, 5) {
+		t.Fatalf("UnmarshalGeoJSON() = %v, %v, want %v", r, err, NewRectangle(0, 0, 10, 5))
+	}
+	var tri Triangle
+	if err := tri.UnmarshalGeoJSON([]byte(`{"type":"Polygon","coordinates":[[[0,0],[9,0],[0,9],[0,0]]]}`)); err != nil || tri.Center != (Coord{3, 3}) || tri.Vertices[2].Index != 2 {
+		t.Fatalf("UnmarshalGeoJSON() = %v, %v", tri, err)
+	}
+}
+
+// checkMeshRoundTrip 校验导出再导入的导航网格与凸多边形与原对象一致，且邻接关系指向新网格内的对象。
+func checkMeshRoundTrip(t *testing.T, m *NavMesh, convexes []*Convex) {
+	t.Helper()
+	b, err := MarshalMeshGeoJSON(m, convexes)
+	if err != nil {
+		t.Fatalf("MarshalMeshGeoJSON() error = %v", err)
+	}
+	m2, cv2, err := UnmarshalMeshGeoJSON(b)
+	if err != nil {
+		t.Fatalf("UnmarshalMeshGeoJSON() error = %v", err)
+	}
+	if !reflect.DeepEqual(m.Vertices, m2.Vertices) || len(m.Triangles) != len(m2.Triangles) || len(m.Edges) != len(m2.Edges) || len(convexes) != len(cv2) {
+		t.Fatalf("UnmarshalMeshGeoJSON() = %d vertices, %d triangles, %d edges, %d convexes, want %d, %d, %d, %d",
+			len(m2.Vertices), len(m2.Triangles), len(m2.Edges), len(cv2), len(m.Vertices), len(m.Triangles), len(m.Edges), len(convexes))
+	}
+	for i, tri := range m.Triangles {
+		t2 := m2.Triangles[i]
+		if !reflect.DeepEqual(tri.Vertices, t2.Vertices) || !reflect.DeepEqual(tri.EdgeIDs, t2.EdgeIDs) || tri.Center != t2.Center || tri.Index != t2.Index {
+			t.Fatalf("triangle %d = %v, want %v", i, t2, tri)
+		}
+	}
+	for i, e := range m.Edges {
+		e2 := m2.Edges[i]
+		if e.Vertices != e2.Vertices || e.IsAdjacency != e2.IsAdjacency || e.WtCoord != e2.WtCoord || len(e.AdjacenctTriangles) != len(e2.AdjacenctTriangles) {
+			t.Fatalf("edge %d = %v, want %v", i, e2, e)
+		}
+		for k, adj := range e2.AdjacenctTriangles {
+			if adj.Index != e.AdjacenctTriangles[k].Index || adj != m2.Triangles[adj.Index] {
+				t.Fatalf("edge %d adjacent triangle %d does not point into the new mesh", i, adj.Index)
+			}
+		}
+		if _, id, ok := m2.FindEdge(e.Vertices[0].Index, e.Vertices[1].Index); !ok || id != int32(i) {
+			t.Fatalf("FindEdge(%d, %d) = %d, %v, want %d", e.Vertices[0].Index, e.Vertices[1].Index, id, ok, i)
+		}
+	}
+	for i, c := range convexes {
+		c2 := cv2[i]
+		if c.Index != c2.Index || !reflect.DeepEqual(c.Vertices, c2.Vertices) || !reflect.DeepEqual(c.EdgeIDs, c2.EdgeIDs) || c.WtCoord != c2.WtCoord || len(c.MergeTriangles) != len(c2.MergeTriangles) {
+			t.Fatalf("convex %d = %v, want %v", i, c2, c)
+		}
+		for k, tri := range c.MergeTriangles {
+			if c2.MergeTriangles[k] != m2.Triangles[tri.Index] {
+				t.Fatalf("convex %d merge triangle %d does not point into the new mesh", i, tri.Index)
+			}
+		}
+	}
+}
+
+func TestMeshGeoJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer []Coord
+		holes [][]Coord
+	}{
+		{"square with hole", []Coord{{0, 0}, {1000, 0}, {1000, 1000}, {0, 1000}}, [][]Coord{{{200, 200}, {800, 200}, {800, 800}, {200, 800}}}},
+		{"triangle", []Coord{{0, 0}, {100, 0}, {0, 100}}, nil},
+		{"star", starRing(7, 500, 200), nil},
+		{"negative coordinates", translateRing(lShape, -5000, -7000), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewNavMeshBuilder(tt.outer, tt.holes...).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			m.Edges[0].WtCoord = Coord{7, 7}
+			convexes, err := m.MergeConvexes()
+			if err != nil {
+				t.Fatalf("MergeConvexes() error = %v", err)
+			}
+			convexes[0].WtCoord = Coord{1, 2}
+			checkMeshRoundTrip(t, m, convexes)
+			checkMeshRoundTrip(t, m, nil)
+		})
+	}
+}
+
+// TestMeshGeoJSONDerivedEdges 校验只保留三角形 Feature（如在 GIS 工具中手工绘制）时，边与邻接关系由三角形推导得到。
+func TestMeshGeoJSONDerivedEdges(t *testing.T) {
+	m := testMesh(t)
+	b, err := m.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON() error = %v", err)
+	}
+	var fc geoJSONObject
+	if err := json.Unmarshal(b, &fc); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	var kept []*geoJSONObject
+	for _, f := range fc.Features {
+		if f.Properties.Kind == geoJSONKindTriangle {
+			kept = append(kept, f)
+		}
+	}
+	fc.Features = kept
+	b, _ = json.Marshal(&fc)
+	var m2 NavMesh
+	if err := m2.UnmarshalGeoJSON(b); err != nil {
+		t.Fatalf("UnmarshalGeoJSON() error = %v", err)
+	}
+	if len(m2.Edges) != len(m.Edges) {
+		t.Fatalf("UnmarshalGeoJSON() = %d edges, want %d", len(m2.Edges), len(m.Edges))
+	}
+	for i, e := range m.Edges {
+		if m2.Edges[i].IsAdjacency != e.IsAdjacency || m2.Edges[i].GenKey() != e.GenKey() {
+			t.Fatalf("derived edge %d = %v, want %v", i, m2.Edges[i], e)
+		}
+	}
+	if again, err := m2.MergeConvexes(); err != nil || len(again) == 0 {
+		t.Fatalf("MergeConvexes() on the derived mesh = %d, %v", len(again), err)
+	}
+}
+
+// TestCoordGeoJSONRandom 校验任意 int32 坐标导出后可以无损导入。
+func TestCoordGeoJSONRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(61, 0))
+	for range 2000 {
+		c := Coord{int32(rng.Uint32()), int32(rng.Uint32())}
+		b, err := c.MarshalGeoJSON()
+		if err != nil {
+			t.Fatalf("MarshalGeoJSON(%v) error = %v", c, err)
+		}
+		var got Coord
+		if err := got.UnmarshalGeoJSON(b); err != nil || got != c {
+			t.Fatalf("UnmarshalGeoJSON(%s) = %v, %v, want %v", b, got, err, c)
+		}
+	}
+}
+
+// TestMeshGeoJSONInconsistent 校验导入的三角形、凸多边形与顶点表、边表不一致或环无效时返回 ErrInvalidGeoJSON。
+func TestMeshGeoJSONInconsistent(t *testing.T) {
+	m := testMesh(t)
+	convexes, err := m.MergeConvexes()
+	if err != nil {
+		t.Fatalf("MergeConvexes() error = %v", err)
+	}
+	b, err := MarshalMeshGeoJSON(m, convexes)
+	if err != nil {
+		t.Fatalf("MarshalMeshGeoJSON() error = %v", err)
+	}
+	// first 返回首个指定类型的 Feature
+	first := func(fc *geoJSONObject, kind string) *geoJSONObject {
+		for _, f := range fc.Features {
+			if f.Properties.Kind == kind {
+				return f
+			}
+		}
+		t.Fatalf("no %s feature", kind)
+		return nil
+	}
+	// moveFirstPosition 将 Feature 外环的首个位置（及闭合点）平移一个单位
+	moveFirstPosition := func(f *geoJSONObject) {
+		var rings [][][]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &rings); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		n := len(rings[0])
+		rings[0][0][0]++
+		rings[0][n-1][0]++
+		f.Geometry.Coordinates, _ = json.Marshal(rings)
+	}
+	tests := []struct {
+		name   string
+		mutate func(fc *geoJSONObject)
+	}{
+		{"triangle vertex moved", func(fc *geoJSONObject) { moveFirstPosition(first(fc, geoJSONKindTriangle)) }},
+		{"convex vertex moved", func(fc *geoJSONObject) { moveFirstPosition(first(fc, geoJSONKindConvex)) }},
+		{"convex edge ids swapped", func(fc *geoJSONObject) {
+			ids := first(fc, geoJSONKindConvex).Properties.EdgeIDs
+			ids[0], ids[1] = ids[1], ids[0]
+		}},
+		{"convex edge id out of range", func(fc *geoJSONObject) { first(fc, geoJSONKindConvex).Properties.EdgeIDs[0] = 1 << 20 }},
+		{"convex edge ids truncated", func(fc *geoJSONObject) {
+			p := first(fc, geoJSONKindConvex).Properties
+			p.EdgeIDs = p.EdgeIDs[:len(p.EdgeIDs)-1]
+		}},
+		{"triangle edge ids swapped", func(fc *geoJSONObject) {
+			ids := first(fc, geoJSONKindTriangle).Properties.EdgeIDs
+			ids[0], ids[1] = ids[1], ids[0]
+		}},
+		{"clockwise triangle", func(fc *geoJSONObject) {
+			f := first(fc, geoJSONKindTriangle)
+			var rings [][][]float64
+			if err := json.Unmarshal(f.Geometry.Coordinates, &rings); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			slices.Reverse(rings[0])
+			f.Geometry.Coordinates, _ = json.Marshal(rings)
+			slices.Reverse(f.Properties.Vertices)
+			f.Properties.EdgeIDs = nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fc geoJSONObject
+			if err := json.Unmarshal(b, &fc); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			tt.mutate(&fc)
+			data, _ := json.Marshal(&fc)
+			if _, _, err := UnmarshalMeshGeoJSON(data); !errors.Is(err, ErrInvalidGeoJSON) {
+				t.Fatalf("UnmarshalMeshGeoJSON() error = %v, want %v", err, ErrInvalidGeoJSON)
+			}
+		})
+	}
+
+	// 顶点表与三角形一致但三角形退化为线段
+	degenerate := `{"type":"FeatureCollection","features":[` +
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":{"kind":"vertex","index":0}},` +
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[5,0]},"properties":{"kind":"vertex","index":1}},` +
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[10,0]},"properties":{"kind":"vertex","index":2}},` +
+		`{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[5,0],[10,0],[0,0]]]},"properties":{"kind":"triangle","index":0,"vertices":[0,1,2],"edgeIDs":[0,1,2]}}]}`
+	if _, _, err := UnmarshalMeshGeoJSON([]byte(degenerate)); !errors.Is(err, ErrInvalidGeoJSON) {
+		t.Fatalf("UnmarshalMeshGeoJSON() error = %v, want %v", err, ErrInvalidGeoJSON)
+	}
+}
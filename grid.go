@@ -0,0 +1,255 @@
+package geo
+
+import (
+	"math"
+	"slices"
+)
+
+// GridIndex 表示均匀网格空间哈希索引，格子划分约定与 GetCrossRect 完全一致：
+// 坐标 (X, Z) 所在格子以 ((X/cellWidth)*cellWidth, (Z/cellHeight)*cellHeight) 为键。
+// 元素既可以按坐标点插入，也可以按轴对齐矩形插入（覆盖的每个格子都会记录该元素）。
+// 适合 AOI 视野管理、弹道检测等元素分布均匀、频繁移动的场景。
+type GridIndex[T comparable] struct {
+	cellWidth  int32
+	cellHeight int32
+	width      int32 // 世界宽度，供 GetCrossRect 绘制网格线
+	height     int32 // 世界高度，供 GetCrossRect 绘制网格线
+	cells      map[Coord][]T
+	entries    map[T]gridEntry[T]
+}
+
+// gridEntry 记录元素的包围矩形及其占据的格子。坐标点元素的宽高为 0。
+type gridEntry[T comparable] struct {
+	rect  Rectangle
+	cells []Coord
+}
+
+// NewGridIndex 以格子宽高及世界宽高创建网格索引。
+func NewGridIndex[T comparable](cellWidth, cellHeight, width, height int32) *GridIndex[T] {
+	return &GridIndex[T]{
+		cellWidth:  max(cellWidth, 1),
+		cellHeight: max(cellHeight, 1),
+		width:      width,
+		height:     height,
+		cells:      make(map[Coord][]T),
+		entries:    make(map[T]gridEntry[T]),
+	}
+}
+
+// Len 返回索引中的元素数量。
+func (g *GridIndex[T]) Len() int {
+	return len(g.entries)
+}
+
+// CellOf 返回坐标点所在格子的键（格子锚点坐标）。
+func (g *GridIndex[T]) CellOf(p Coord) Coord {
+	return Coord{X: (p.X / g.cellWidth) * g.cellWidth, Z: (p.Z / g.cellHeight) * g.cellHeight}
+}
+
+// Insert 以坐标点插入元素，元素已存在时等价于 Move。
+func (g *GridIndex[T]) Insert(item T, p Coord) {
+	g.InsertRect(item, NewRectangle(p.X, p.Z, 0, 0))
+}
+
+// InsertRect 以轴对齐矩形插入元素，矩形覆盖的每个格子都会记录该元素。
+// 元素已存在时等价于 MoveRect。
+func (g *GridIndex[T]) InsertRect(item T, rect Rectangle) {
+	if _, ok := g.entries[item]; ok {
+		g.MoveRect(item, rect)
+		return
+	}
+	cells := g.cellsOf(rect)
+	for _, c := range cells {
+		g.cells[c] = append(g.cells[c], item)
+	}
+	g.entries[item] = gridEntry[T]{rect: rect, cells: cells}
+}
+
+// Move 将元素移动到新的坐标点。
+// 新旧位置处于同一格子时仅更新坐标，不触碰格子存储，移动开销为 O(1)。
+func (g *GridIndex[T]) Move(item T, p Coord) {
+	g.MoveRect(item, NewRectangle(p.X, p.Z, 0, 0))
+}
+
+// MoveRect 将元素更新为新的包围矩形，仅对新增和离开的格子做增删。
+// 元素不存在时等价于 InsertRect。
+func (g *GridIndex[T]) MoveRect(item T, rect Rectangle) {
+	e, ok := g.entries[item]
+	if !ok {
+		g.InsertRect(item, rect)
+		return
+	}
+	cells := g.cellsOf(rect)
+	if !slices.Equal(cells, e.cells) {
+		for _, c := range e.cells {
+			if !slices.Contains(cells, c) {
+				g.removeFromCell(c, item)
+			}
+		}
+		for _, c := range cells {
+			if !slices.Contains(e.cells, c) {
+				g.cells[c] = append(g.cells[c], item)
+			}
+		}
+	}
+	g.entries[item] = gridEntry[T]{rect: rect, cells: cells}
+}
+
+// Remove 删除元素，元素不存在时返回 false。
+func (g *GridIndex[T]) Remove(item T) bool {
+	e, ok := g.entries[item]
+	if !ok {
+		return false
+	}
+	for _, c := range e.cells {
+		g.removeFromCell(c, item)
+	}
+	delete(g.entries, item)
+	return true
+}
+
+// Bounds 返回元素当前的包围矩形，坐标点元素的宽高为 0。
+func (g *GridIndex[T]) Bounds(item T) (Rectangle, bool) {
+	e, ok := g.entries[item]
+	return e.rect, ok
+}
+
+// removeFromCell 从格子中移除元素，格子为空时删除该格子。
+func (g *GridIndex[T]) removeFromCell(c Coord, item T) {
+	items := g.cells[c]
+	for i, it := range items {
+		if it == item {
+			items[i] = items[len(items)-1]
+			items = items[:len(items)-1]
+			break
+		}
+	}
+	if len(items) == 0 {
+		delete(g.cells, c)
+		return
+	}
+	g.cells[c] = items
+}
+
+// cellsOf 返回矩形覆盖的全部格子键，按 Z、X 递增排列。
+// 右上角坐标饱和到 int32 范围内，格子键以 int64 步进，靠近 math.MaxInt32 时不会回绕成死循环。
+func (g *GridIndex[T]) cellsOf(rect Rectangle) []Coord {
+	return g.cellsBetween(rect.Coord, Coord{X: addInt32(rect.X, rect.Width), Z: addInt32(rect.Z, rect.Height)})
+}
+
+// cellsBetween 返回以 lo、hi 为左下角与右上角的区域覆盖的全部格子键，按 Z、X 递增排列。
+func (g *GridIndex[T]) cellsBetween(lo, hi Coord) []Coord {
+	minCell, maxCell := g.CellOf(lo), g.CellOf(hi)
+	cells := make([]Coord, 0, 1)
+	for z := int64(minCell.Z); z <= int64(maxCell.Z); z += int64(g.cellHeight) {
+		for x := int64(minCell.X); x <= int64(maxCell.X); x += int64(g.cellWidth) {
+			cells = append(cells, Coord{X: int32(x), Z: int32(z)})
+		}
+	}
+	return cells
+}
+
+// collect 遍历格子中的元素，对每个尚未访问的元素调用 match，返回匹配的元素。
+func (g *GridIndex[T]) collect(cells []Coord, match func(rect Rectangle) bool) []T {
+	var ret []T
+	seen := map[T]bool{}
+	for _, c := range cells {
+		for _, item := range g.cells[c] {
+			if seen[item] {
+				continue
+			}
+			seen[item] = true
+			if match(g.entries[item].rect) {
+				ret = append(ret, item)
+			}
+		}
+	}
+	return ret
+}
+
+// QueryRect 返回包围矩形与给定矩形重叠（含边界接触）的所有元素。
+func (g *GridIndex[T]) QueryRect(rect Rectangle) []T {
+	return g.collect(g.cellsOf(rect), func(r Rectangle) bool {
+		return r.X <= addInt32(rect.X, rect.Width) && rect.X <= addInt32(r.X, r.Width) &&
+			r.Z <= addInt32(rect.Z, rect.Height) && rect.Z <= addInt32(r.Z, r.Height)
+	})
+}
+
+// QueryCircle 返回与圆相交的所有元素：坐标点元素须在圆内，矩形元素须与圆有重叠。
+// 矩形与圆的判定取矩形内距圆心最近的点，比较其到圆心的距离平方与半径平方。
+// 圆的包围盒与矩形的右上角均以 int64 计算，包围盒截断到 int32 范围后再换算为格子，靠近 math.MaxInt32 时不会回绕。
+func (g *GridIndex[T]) QueryCircle(c Circle) []T {
+	cx, cz, radius := int64(c.Center.X), int64(c.Center.Z), int64(c.Radius)
+	clamp := func(v int64) int32 {
+		return int32(min(max(v, math.MinInt32), math.MaxInt32))
+	}
+	lo := Coord{X: clamp(cx - radius), Z: clamp(cz - radius)}
+	hi := Coord{X: clamp(cx + radius), Z: clamp(cz + radius)}
+	radiusSquared := float64(radius) * float64(radius)
+	return g.collect(g.cellsBetween(lo, hi), func(r Rectangle) bool {
+		dx := max(int64(r.X), min(cx, int64(r.X)+int64(r.Width))) - cx
+		dz := max(int64(r.Z), min(cz, int64(r.Z)+int64(r.Height))) - cz
+		return float64(dx)*float64(dx)+float64(dz)*float64(dz) <= radiusSquared
+	})
+}
+
+// QuerySegment 返回与线段相交的所有元素：坐标点元素须落在线段上，矩形元素须与线段有公共点。
+// 候选格子由 GetCrossRect 计算，与网格划分约定保持一致。
+func (g *GridIndex[T]) QuerySegment(s Segment) []T {
+	return g.collect(g.lineCells(s.A, s.B), func(r Rectangle) bool {
+		return isSegmentCrossRect(s.A, s.B, r)
+	})
+}
+
+// RangeLine 按从 p0 到 p1 的顺序遍历线段经过的格子，回调格子键及格子内的元素。
+// 回调返回 false 时提前终止遍历，适合弹道沿途的逐格检测。
+func (g *GridIndex[T]) RangeLine(p0, p1 Coord, fn func(cell Coord, items []T) bool) {
+	for _, c := range g.lineCells(p0, p1) {
+		if !fn(c, g.cells[c]) {
+			return
+		}
+	}
+}
+
+// lineCells 返回线段经过的格子键，按格子中心在 p0→p1 方向上的投影从近到远排列。
+func (g *GridIndex[T]) lineCells(p0, p1 Coord) []Coord {
+	set := GetCrossRect(p0, p1, g.cellWidth, g.cellHeight, g.width, g.height)
+	cells := make([]Coord, 0, len(set))
+	for c := range set {
+		cells = append(cells, c)
+	}
+	dir := NewVector(p0, p1)
+	proj := func(c Coord) float64 {
+		center := Coord{X: c.X + g.cellWidth/2, Z: c.Z + g.cellHeight/2}
+		v := NewVector(p0, center)
+		return dir.Dot(&v)
+	}
+	slices.SortFunc(cells, func(a, b Coord) int {
+		pa, pb := proj(a), proj(b)
+		switch {
+		case pa < pb:
+			return -1
+		case pa > pb:
+			return 1
+		case a.Z != b.Z:
+			return int(a.Z) - int(b.Z)
+		}
+		return int(a.X) - int(b.X)
+	})
+	return cells
+}
+
+// isSegmentCrossRect 判断线段与轴对齐矩形是否有公共点（含边界），宽高为 0 的矩形退化为点或线段。
+// 分离轴判定：包围盒在 X、Z 轴上重叠，且矩形四个顶点不全严格位于线段所在直线的同一侧。
+func isSegmentCrossRect(a, b Coord, r Rectangle) bool {
+	if !IsRectCross(a, b, r.Coord, Coord{X: r.X + r.Width, Z: r.Z + r.Height}) {
+		return false
+	}
+	var left, right bool
+	for _, p := range r.GetVerticeCoords() {
+		c := cross(b, p, a)
+		left = left || c >= 0
+		right = right || c <= 0
+	}
+	return left && right
+}
@@ -0,0 +1,171 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestGridIndexQuery(t *testing.T) {
+	g := NewGridIndex[string](100, 100, 1000, 1000)
+	g.Insert("a", Coord{50, 50})
+	g.Insert("b", Coord{150, 50})
+	g.InsertRect("c", NewRectangle(180, 180, 240, 40))
+	g.Insert("d", Coord{900, 900})
+	g.Move("d", Coord{950, 950})
+	g.InsertRect("e", NewRectangle(math.MaxInt32-1500, math.MaxInt32-1500, 3000, 3000))
+	tests := []struct {
+		name  string
+		query func() []string
+		want  []string
+	}{
+		{"rect single cell", func() []string { return g.QueryRect(NewRectangle(0, 0, 60, 60)) }, []string{"a"}},
+		{"rect touching point", func() []string { return g.QueryRect(NewRectangle(150, 0, 10, 50)) }, []string{"b"}},
+		{"rect across cells", func() []string { return g.QueryRect(NewRectangle(0, 0, 200, 200)) }, []string{"a", "b", "c"}},
+		{"rect after move", func() []string { return g.QueryRect(NewRectangle(900, 900, 10, 10)) }, nil},
+		{"rect near MaxInt32", func() []string {
+			return g.QueryRect(NewRectangle(math.MaxInt32-100, math.MaxInt32-100, 100, 100))
+		}, []string{"e"}},
+		{"circle", func() []string { return g.QueryCircle(NewCirCle(Coord{100, 50}, 50)) }, []string{"a", "b"}},
+		{"circle near rect corner", func() []string { return g.QueryCircle(NewCirCle(Coord{170, 170}, 15)) }, []string{"c"}},
+		{"circle near MaxInt32", func() []string {
+			return g.QueryCircle(NewCirCle(Coord{math.MaxInt32 - 10, math.MaxInt32 - 10}, 100))
+		}, []string{"e"}},
+		{"circle near MaxInt32 misses", func() []string {
+			return g.QueryCircle(NewCirCle(Coord{math.MaxInt32 - 10, 500}, 100))
+		}, nil},
+		{"segment through rect", func() []string { return g.QuerySegment(NewSegment(Coord{300, 0}, Coord{300, 1000})) }, []string{"c"}},
+		{"segment through point", func() []string { return g.QuerySegment(NewSegment(Coord{0, 0}, Coord{100, 100})) }, []string{"a"}},
+		{"segment missing", func() []string { return g.QuerySegment(NewSegment(Coord{0, 100}, Coord{100, 150})) }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query()
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if g.Len() != 5 {
+		t.Fatalf("Len() = %d, want 5", g.Len())
+	}
+	if !g.Remove("e") || g.Remove("e") {
+		t.Fatal("Remove() should succeed once")
+	}
+}
+
+func TestGridIndexMatchesBruteForce(t *testing.T) {
+	tests := []struct {
+		name                  string
+		cellWidth, cellHeight int32
+		seed                  uint64
+	}{
+		{"square cells", 100, 100, 1},
+		{"rectangular cells", 100, 80, 3},
+		{"tiny cells", 7, 13, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(tt.seed, 0))
+			g := NewGridIndex[int](tt.cellWidth, tt.cellHeight, 2000, 2000)
+			rects := map[int]Rectangle{}
+			for i := range 300 {
+				x, z := rng.Int32N(1900), rng.Int32N(1900)
+				if i%2 == 0 {
+					g.Insert(i, Coord{x, z})
+					rects[i] = NewRectangle(x, z, 0, 0)
+				} else {
+					r := NewRectangle(x, z, rng.Int32N(100), rng.Int32N(100))
+					g.InsertRect(i, r)
+					rects[i] = r
+				}
+			}
+			for i := 0; i < 300; i += 3 {
+				r := NewRectangle(rng.Int32N(1900), rng.Int32N(1900), rects[i].Width, rects[i].Height)
+				g.MoveRect(i, r)
+				rects[i] = r
+			}
+			for i := 0; i < 300; i += 7 {
+				g.Remove(i)
+				delete(rects, i)
+			}
+			if g.Len() != len(rects) {
+				t.Fatalf("Len() = %d, want %d", g.Len(), len(rects))
+			}
+			for c, items := range g.cells {
+				for _, it := range items {
+					if !slices.Contains(g.entries[it].cells, c) {
+						t.Fatalf("cell %v holds stale item %d", c, it)
+					}
+				}
+			}
+			count := func(match func(r Rectangle) bool) int {
+				n := 0
+				for _, r := range rects {
+					if match(r) {
+						n++
+					}
+				}
+				return n
+			}
+			for range 200 {
+				q := NewRectangle(rng.Int32N(1800), rng.Int32N(1800), rng.Int32N(300), rng.Int32N(300))
+				want := count(func(r Rectangle) bool {
+					return r.X <= q.X+q.Width && q.X <= r.X+r.Width && r.Z <= q.Z+q.Height && q.Z <= r.Z+r.Height
+				})
+				if got := g.QueryRect(q); len(got) != want {
+					t.Fatalf("QueryRect(%v) returned %d items, want %d", q, len(got), want)
+				}
+				c := NewCirCle(Coord{rng.Int32N(1800) + 100, rng.Int32N(1800) + 100}, rng.Int32N(100))
+				want = count(func(r Rectangle) bool {
+					n := Coord{max(r.X, min(c.Center.X, r.X+r.Width)), max(r.Z, min(c.Center.Z, r.Z+r.Height))}
+					return CalDstCoordToCoordWithoutSqrt(n, c.Center) <= float64(c.Radius)*float64(c.Radius)
+				})
+				if got := g.QueryCircle(c); len(got) != want {
+					t.Fatalf("QueryCircle(%v) returned %d items, want %d", c, len(got), want)
+				}
+				s := NewSegment(Coord{rng.Int32N(1990) + 1, rng.Int32N(1990) + 1}, Coord{rng.Int32N(1990) + 1, rng.Int32N(1990) + 1})
+				want = count(func(r Rectangle) bool { return isSegmentCrossRect(s.A, s.B, r) })
+				if got := g.QuerySegment(s); len(got) != want {
+					t.Fatalf("QuerySegment(%v) returned %d items, want %d", s, len(got), want)
+				}
+				// RangeLine 按格子中心在线段方向上的投影从近到远遍历
+				d := NewVector(s.A, s.B)
+				prev := math.Inf(-1)
+				g.RangeLine(s.A, s.B, func(cell Coord, _ []int) bool {
+					v := NewVector(s.A, Coord{cell.X + tt.cellWidth/2, cell.Z + tt.cellHeight/2})
+					if d.Dot(&v) < prev {
+						t.Fatalf("RangeLine visited %v out of order", cell)
+					}
+					prev = d.Dot(&v)
+					return true
+				})
+			}
+		})
+	}
+}
+
+func TestIsSegmentCrossRect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Coord
+		r    Rectangle
+		want bool
+	}{
+		{"point on segment", Coord{0, 0}, Coord{10, 10}, NewRectangle(5, 5, 0, 0), true},
+		{"point off segment", Coord{0, 0}, Coord{10, 10}, NewRectangle(5, 6, 0, 0), false},
+		{"crosses rect", Coord{0, 5}, Coord{20, 5}, NewRectangle(5, 0, 5, 10), true},
+		{"touches corner", Coord{0, 10}, Coord{10, 0}, NewRectangle(5, 5, 5, 5), true},
+		{"passes diagonal gap", Coord{0, 11}, Coord{11, 0}, NewRectangle(6, 6, 5, 5), false},
+		{"ends before rect", Coord{0, 5}, Coord{4, 5}, NewRectangle(5, 0, 5, 10), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSegmentCrossRect(tt.a, tt.b, tt.r); got != tt.want {
+				t.Fatalf("isSegmentCrossRect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ConvexHull 使用 Andrew 单调链算法（Monotone Chain）求点集的凸包，时间复杂度 O(n log n)。
+// 点按 (X, Z) 排序后分别构建下凸链与上凸链，以整数叉积 cross 判断转向，全程无浮点误差。
+//
+// 返回的凸包顶点按逆时针排列，从 X 最小（相同时 Z 最小）的点开始，不含重复点与共线点，不修改输入切片。
+// 不足 3 个不共线的点时返回去重后的端点：空输入返回 nil，单点返回 1 个点，共线点集返回两端点。
+func ConvexHull(coords []Coord) []Coord {
+	points := slices.Clone(coords)
+	slices.SortFunc(points, func(a, b Coord) int {
+		return cmp.Or(cmp.Compare(a.X, b.X), cmp.Compare(a.Z, b.Z))
+	})
+	points = slices.Compact(points)
+	if len(points) < 3 {
+		return points
+	}
+
+	hull := make([]Coord, 0, len(points)+1)
+	// 下凸链：从左到右，非左转（叉积 <= 0）的点出栈
+	for _, p := range points {
+		for len(hull) >= 2 && cross(hull[len(hull)-1], p, hull[len(hull)-2]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// 上凸链：从右到左，起点为下凸链的终点
+	lower := len(hull)
+	for i := len(points) - 2; i >= 0; i-- {
+		p := points[i]
+		for len(hull) > lower && cross(hull[len(hull)-1], p, hull[len(hull)-2]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// 末尾的点即为起点
+	return hull[:len(hull)-1]
+}
+
+// NewConvexFromCoords 以点集的凸包创建凸多边形，用于阵型包围范围、框选单位等场景。
+// 顶点已按逆时针排列，可直接用于 GetVectors、IsCoordInside 与 Circle.IsInterPolygon；
+// 各顶点的 Index 为该坐标在 coords 中首次出现的下标，EdgeIDs 与 MergeTriangles 为空。
+// 凸包不足 3 个顶点（点集为空、单点或共线）时返回 ErrInvalidRing。
+func NewConvexFromCoords(coords []Coord, id int32) (*Convex, error) {
+	hull := ConvexHull(coords)
+	if len(hull) < 3 {
+		return nil, ErrInvalidRing
+	}
+	first := make(map[Coord]int32, len(coords))
+	for i := len(coords) - 1; i >= 0; i-- {
+		first[coords[i]] = int32(i)
+	}
+	vertices := make([]Vertice, len(hull))
+	for i, c := range hull {
+		vertices[i] = Vertice{Index: first[c], Coord: c}
+	}
+	return &Convex{
+		Index:    id,
+		Vertices: vertices,
+	}, nil
+}
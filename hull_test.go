@@ -0,0 +1,104 @@
+package geo
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestConvexHull(t *testing.T) {
+	tests := []struct {
+		name   string
+		coords []Coord
+		want   []Coord
+	}{
+		{"empty", nil, nil},
+		{"single point", []Coord{{3, 4}}, []Coord{{3, 4}}},
+		{"duplicate points", []Coord{{3, 4}, {3, 4}, {3, 4}}, []Coord{{3, 4}}},
+		{"collinear", []Coord{{0, 0}, {1, 1}, {2, 2}, {1, 1}}, []Coord{{0, 0}, {2, 2}}},
+		{"vertical collinear", []Coord{{5, 9}, {5, 0}, {5, 3}}, []Coord{{5, 0}, {5, 9}}},
+		{"triangle clockwise input", []Coord{{0, 0}, {0, 10}, {10, 0}}, []Coord{{0, 0}, {10, 0}, {0, 10}}},
+		{"square with inner and edge points", []Coord{{5, 5}, {0, 0}, {10, 0}, {5, 0}, {10, 10}, {0, 10}, {3, 7}, {10, 10}, {0, 5}},
+			[]Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{"same minimum X", []Coord{{0, 5}, {0, 0}, {4, 2}, {0, 10}}, []Coord{{0, 0}, {4, 2}, {0, 10}}},
+		{"large coordinates", []Coord{{-2147483648, -2147483648}, {2147483647, -2147483648}, {0, 2147483647}, {0, 0}},
+			[]Coord{{-2147483648, -2147483648}, {2147483647, -2147483648}, {0, 2147483647}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.coords)
+			if got := ConvexHull(tt.coords); !slices.Equal(got, tt.want) {
+				t.Fatalf("ConvexHull() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(input, tt.coords) {
+				t.Fatalf("ConvexHull() modified the input to %v", tt.coords)
+			}
+		})
+	}
+}
+
+func TestNewConvexFromCoords(t *testing.T) {
+	coords := []Coord{{5, 5}, {0, 0}, {10, 0}, {5, 0}, {10, 10}, {0, 10}, {3, 7}, {10, 10}, {0, 5}}
+	c, err := NewConvexFromCoords(coords, 7)
+	if err != nil {
+		t.Fatalf("NewConvexFromCoords() = %v", err)
+	}
+	want := []Vertice{{Index: 1, Coord: Coord{0, 0}}, {Index: 2, Coord: Coord{10, 0}}, {Index: 4, Coord: Coord{10, 10}}, {Index: 5, Coord: Coord{0, 10}}}
+	if c.Index != 7 || !slices.Equal(c.Vertices, want) {
+		t.Fatalf("NewConvexFromCoords() = %v, want index 7 and vertices %v", c, want)
+	}
+	overlapping, apart := NewCirCle(Coord{13, 5}, 4), NewCirCle(Coord{15, 5}, 4)
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"inside", c.IsCoordInside(Coord{5, 5}), true},
+		{"on edge", c.IsCoordInside(Coord{10, 5}), true},
+		{"outside", c.IsCoordInside(Coord{11, 5}), false},
+		{"circle overlapping", overlapping.IsInterPolygon(c.GetVectors()), true},
+		{"circle apart", apart.IsInterPolygon(c.GetVectors()), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+	for _, degenerate := range [][]Coord{nil, {{1, 1}}, {{0, 0}, {1, 1}, {2, 2}}} {
+		if _, err := NewConvexFromCoords(degenerate, 0); !errors.Is(err, ErrInvalidRing) {
+			t.Fatalf("NewConvexFromCoords(%v) = %v, want %v", degenerate, err, ErrInvalidRing)
+		}
+	}
+}
+
+// TestConvexHullRandom 校验随机点集的凸包严格凸、逆时针且包含全部点，顶点均来自输入。
+func TestConvexHullRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 9))
+	for range 300 {
+		coords := make([]Coord, 3+rng.IntN(60))
+		for i := range coords {
+			coords[i] = Coord{rng.Int32N(100), rng.Int32N(100)}
+		}
+		h := ConvexHull(coords)
+		if len(h) < 3 {
+			continue
+		}
+		for i := range h {
+			a, b, c := h[i], h[(i+1)%len(h)], h[(i+2)%len(h)]
+			if cross(b, c, a) <= 0 {
+				t.Fatalf("ConvexHull(%v) = %v is not strictly convex at %v", coords, h, b)
+			}
+			if !slices.Contains(coords, a) {
+				t.Fatalf("ConvexHull(%v) = %v contains foreign point %v", coords, h, a)
+			}
+			for _, p := range coords {
+				if cross(b, p, a) < 0 {
+					t.Fatalf("ConvexHull(%v) = %v leaves out %v", coords, h, p)
+				}
+			}
+		}
+	}
+}
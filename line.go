@@ -12,19 +12,28 @@ type Line struct {
 // NewLine 通过两个坐标点构造直线方程 A*x + B*z + C = 0。
 // 推导过程：直线经过点 a(xa, za) 和 b(xb, zb)，
 // 则 A = zb - za，B = xa - xb，C = xb*za - xa*zb。
-// 全部使用 int64 运算，避免 int32 溢出。
+// 全部使用 int64 运算，避免 int32 溢出；坐标取 int32 全范围时 |C| ≤ 2^63 - 2^31，恰好能以 int64 精确表示。
 func NewLine(a, b Coord) Line {
 	l := Line{
 		A: int64(b.Z) - int64(a.Z),
 		B: int64(a.X) - int64(b.X),
 	}
-	l.C = int64(b.X)*int64(a.Z) - int64(a.X)*int64(b.Z)
+	// 以 128 位整数精确求差，结果的高位只是低位的符号扩展，取低 64 位即为精确值
+	c := mul128(int64(b.X), int64(a.Z)).sub(mul128(int64(a.X), int64(b.Z)))
+	l.C = int64(c.lo)
 	return l
 }
 
 // IsCoordOnLine 判断给定坐标点是否严格位于直线上（代入方程后结果为 0）。
 func (l Line) IsCoordOnLine(c Coord) bool {
-	return l.A*int64(c.X)+l.B*int64(c.Z)+l.C == 0
+	return l.Side(c) == 0
+}
+
+// Side 返回点代入直线方程 A*x + B*z + C 后结果的符号（-1、0 或 1），用于判断点位于直线的哪一侧。
+// 由 NewLine(a, b) 构造时，结果为正表示点在 a→b 的右侧，与 Orient2D(a, b, c) 的符号相反。
+// 各项乘积与求和以 128 位整数精确计算，系数与坐标取任意值都不会溢出。
+func (l Line) Side(c Coord) int {
+	return mul128(l.A, int64(c.X)).add(mul128(l.B, int64(c.Z))).add(mul128(l.C, 1)).sign()
 }
 
 // IsValid 判断直线方程是否有效（A 和 B 不能同时为 0，否则方程退化为常数）。
@@ -0,0 +1,115 @@
+package geo
+
+import "math"
+
+const (
+	locatorMaxDepth = 16 // 定位器四叉树的最大深度
+	locatorCapacity = 8  // 定位器四叉树叶子节点容量
+)
+
+// Locator 表示导航网格的点定位服务，一次构建后可反复查询坐标所在的多边形。
+// 内部以多边形包围盒（ToRect）建立四叉树索引，先粗筛候选再用 IsCoordInside 精确判断，
+// 查询复杂度约为 O(log n)，替代逐个多边形线性调用 IsCoordInside 的做法。
+type Locator struct {
+	tree     *QuadTree[Polygon]
+	border   Border
+	boundary map[Polygon][]Segment // 多边形 → 位于网格边界上的边（不与其它多边形共享）
+}
+
+// NewLocator 以多边形集合构建定位器，根区域取所有多边形包围盒的并集。
+// 仅被一个多边形使用的边视为网格边界，供 Nearest 吸附使用。
+// 公共边按端点坐标判定而不依赖顶点序号，顶点序号不是全局唯一的多边形（如未设置 VertexIDs 的 SimplePolygon）也适用。
+func NewLocator(polygons []Polygon) *Locator {
+	minX, minZ := int32(math.MaxInt32), int32(math.MaxInt32)
+	maxX, maxZ := int32(math.MinInt32), int32(math.MinInt32)
+	edgeCount := make(map[[2]Coord]int, len(polygons)*3)
+	for _, p := range polygons {
+		x0, z0, x1, z1 := p.ToRect()
+		minX, minZ = min(minX, x0), min(minZ, z0)
+		maxX, maxZ = max(maxX, x1), max(maxZ, z1)
+		vertices := p.GetVertices()
+		for i := range vertices {
+			edgeCount[coordEdgeKey(vertices[i].Coord, vertices[(i+1)%len(vertices)].Coord)]++
+		}
+	}
+	if len(polygons) == 0 {
+		minX, minZ, maxX, maxZ = 0, 0, 0, 0
+	}
+
+	l := &Locator{
+		border:   NewBorder(minX, minZ, max(maxX-minX, 1), max(maxZ-minZ, 1)),
+		boundary: make(map[Polygon][]Segment),
+	}
+	l.tree = NewQuadTree[Polygon](l.border, locatorMaxDepth, locatorCapacity)
+	for _, p := range polygons {
+		l.tree.Insert(p)
+		vertices := p.GetVertices()
+		for i := range vertices {
+			a, b := vertices[i], vertices[(i+1)%len(vertices)]
+			if edgeCount[coordEdgeKey(a.Coord, b.Coord)] == 1 {
+				l.boundary[p] = append(l.boundary[p], NewSegment(a.Coord, b.Coord))
+			}
+		}
+	}
+	return l
+}
+
+// Locate 返回包含给定点的多边形（含边界），点不在网格内时返回 false。
+// 点恰好落在相邻多边形的公共边上时，返回索引中先命中的那一个。
+func (l *Locator) Locate(p Coord) (Polygon, bool) {
+	for _, polygon := range l.tree.QueryCoord(p) {
+		if polygon.IsCoordInside(p) {
+			return polygon, true
+		}
+	}
+	return nil, false
+}
+
+// Nearest 将点吸附到导航网格上：点在网格内时原样返回，
+// 否则返回网格边界上距离该点最近的点（由 Segment.ClosestPoint 求得）及其所属多边形。
+// 以点为中心的查询窗口从小到大倍增，直到窗口半径不小于当前最近距离，
+// 此时窗口外的边不可能更近，搜索即可终止。网格为空时返回 false。
+func (l *Locator) Nearest(p Coord) (Coord, Polygon, bool) {
+	if polygon, ok := l.Locate(p); ok {
+		return p, polygon, true
+	}
+
+	var nearest Coord
+	var owner Polygon
+	best := math.MaxFloat64
+	bx0, bz0 := int64(l.border.X), int64(l.border.Z)
+	bx1, bz1 := bx0+int64(l.border.Width), bz0+int64(l.border.Height)
+	for r := int64(64); ; r *= 2 {
+		// 查询窗口裁剪到网格区域内，避免宽高超出 int32 范围
+		x0, z0 := max(int64(p.X)-r, bx0), max(int64(p.Z)-r, bz0)
+		x1, z1 := min(int64(p.X)+r, bx1), min(int64(p.Z)+r, bz1)
+		if x0 <= x1 && z0 <= z1 {
+			rect := NewRectangle(int32(x0), int32(z0), int32(x1-x0), int32(z1-z0))
+			for _, polygon := range l.tree.QueryRect(rect) {
+				for _, seg := range l.boundary[polygon] {
+					c := seg.ClosestPoint(p)
+					if d := CalDstCoordToCoord(p, c); d < best {
+						best, nearest, owner = d, c, polygon
+					}
+				}
+			}
+		}
+		// 窗口已覆盖最近距离，或已覆盖整个网格区域
+		covered := int64(p.X)-r <= bx0 && int64(p.Z)-r <= bz0 && int64(p.X)+r >= bx1 && int64(p.Z)+r >= bz1
+		if best <= float64(r) || covered {
+			break
+		}
+	}
+	if owner == nil {
+		return Coord{}, nil, false
+	}
+	return nearest, owner, true
+}
+
+// coordEdgeKey 返回与端点顺序无关的边键，作用同 GenEdgeKey，但以坐标代替顶点序号。
+func coordEdgeKey(a, b Coord) [2]Coord {
+	if b.X < a.X || (b.X == a.X && b.Z < a.Z) {
+		a, b = b, a
+	}
+	return [2]Coord{a, b}
+}
@@ -0,0 +1,90 @@
+package geo
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLocator(t *testing.T) {
+	m := testMesh(t)
+	polygons := make([]Polygon, len(m.Triangles))
+	for i, tri := range m.Triangles {
+		polygons[i] = tri
+	}
+	l := NewLocator(polygons)
+	tests := []struct {
+		name    string
+		p       Coord
+		inside  bool
+		nearest Coord
+	}{
+		{name: "inside corridor", p: Coord{100, 100}, inside: true, nearest: Coord{100, 100}},
+		{name: "on outer vertex", p: Coord{0, 0}, inside: true, nearest: Coord{0, 0}},
+		{name: "on hole edge", p: Coord{500, 200}, inside: true, nearest: Coord{500, 200}},
+		{name: "left of mesh", p: Coord{-50, 300}, nearest: Coord{0, 300}},
+		{name: "beyond corner", p: Coord{1100, 1200}, nearest: Coord{1000, 1000}},
+		{name: "inside hole near edge", p: Coord{500, 230}, nearest: Coord{500, 200}},
+		{name: "far away", p: Coord{-1 << 30, 500}, nearest: Coord{0, 500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := l.Locate(tt.p)
+			if ok != tt.inside {
+				t.Fatalf("Locate(%v) ok = %v, want %v", tt.p, ok, tt.inside)
+			}
+			if ok && !got.IsCoordInside(tt.p) {
+				t.Fatalf("Locate(%v) returned a polygon not containing the point", tt.p)
+			}
+			c, owner, ok := l.Nearest(tt.p)
+			if !ok || c != tt.nearest {
+				t.Fatalf("Nearest(%v) = %v, %v, want %v", tt.p, c, ok, tt.nearest)
+			}
+			if !owner.IsCoordInside(c) {
+				t.Fatalf("Nearest(%v) owner does not contain %v", tt.p, c)
+			}
+		})
+	}
+}
+
+func TestLocatorMatchesBruteForce(t *testing.T) {
+	m := testMesh(t)
+	polygons := make([]Polygon, len(m.Triangles))
+	for i, tri := range m.Triangles {
+		polygons[i] = tri
+	}
+	l := NewLocator(polygons)
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 2000 {
+		p := Coord{r.Int32N(1400) - 200, r.Int32N(1400) - 200}
+		inside := false
+		for _, q := range polygons {
+			inside = inside || q.IsCoordInside(p)
+		}
+		if _, ok := l.Locate(p); ok != inside {
+			t.Fatalf("Locate(%v) ok = %v, want %v", p, ok, inside)
+		}
+		if inside {
+			continue
+		}
+		best := 1e18
+		for _, segs := range l.boundary {
+			for _, s := range segs {
+				best = min(best, s.CalCoordDst(p))
+			}
+		}
+		// ClosestPoint 取整到整数坐标，允许 1 个单位的误差
+		if c, _, _ := l.Nearest(p); CalDstCoordToCoord(p, c) > best+1 {
+			t.Fatalf("Nearest(%v) = %v at %.2f, want distance %.2f", p, c, CalDstCoordToCoord(p, c), best)
+		}
+	}
+}
+
+func TestLocatorEmpty(t *testing.T) {
+	l := NewLocator(nil)
+	if _, ok := l.Locate(Coord{}); ok {
+		t.Fatal("Locate() on empty locator ok = true")
+	}
+	if _, _, ok := l.Nearest(Coord{1, 1}); ok {
+		t.Fatal("Nearest() on empty locator ok = true")
+	}
+}
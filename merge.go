@@ -0,0 +1,153 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// MergeTrianglesToConvexes 使用 Hertel–Mehlhorn 贪心策略将三角网格合并为尽量少的凸多边形。
+// 按输入顺序取尚未合并的三角形作为种子创建 Convex，再反复吸纳与其共享一条边界边的相邻三角形，
+// 只要 MergeTriangle 校验合并后仍为凸多边形即接受，直至无法继续扩张。
+//
+// 边的邻接关系由三角形的 EdgeIDs 推导：被两个输入三角形共享的边视为可通行（IsAdjacency），
+// 只属于一个三角形的边为网格边界。三角形的第 k 条边须连接 Vertices[k] 与 Vertices[(k+1)%3]
+// （NavMeshBuilder 的输出满足该约定）。需要保留被标记为阻挡的公共边（如门、可破坏的墙）时，
+// 使用 NavMesh.MergeConvexes，它按 NavMesh.Edges 的 IsAdjacency 判断可通行性。
+//
+// 输出的 Convex 序号按创建顺序从 0 开始连续分配，顶点统一为逆时针排列，
+// EdgeIDs[i] 对应连接 Vertices[i] 与 Vertices[(i+1)%n] 的边，找不到对应边序号时记为 -1。
+// 本函数不报告校验失败，需要错误信息时使用 NavMesh.MergeConvexes 或对结果调用 Convex.Validate。
+func MergeTrianglesToConvexes(triangles []*Triangle) []*Convex {
+	convexes, _ := mergeTriangles(triangles, func(int32) bool { return true })
+	return convexes
+}
+
+// MergeConvexes 将导航网格的全部三角形合并为凸多边形，参见 MergeTrianglesToConvexes。
+// IsAdjacency 为 false 的边不会被合并穿越。每个凸多边形都会经过 Validate 校验，
+// 凸多边形的某条边找不到对应的边序号时同样报告错误，所有失败原因合并为一个错误返回。
+func (m *NavMesh) MergeConvexes() ([]*Convex, error) {
+	return mergeTriangles(m.Triangles, func(id int32) bool {
+		e := m.GetEdge(id)
+		return e == nil || e.IsAdjacency
+	})
+}
+
+// mergeTriangles 是 MergeTrianglesToConvexes 的实现，passable 判断共享边是否允许被合并穿越。
+func mergeTriangles(triangles []*Triangle, passable func(id int32) bool) ([]*Convex, error) {
+	edgeTris := make(map[int32][]*Triangle, len(triangles)*3/2)
+	edgeIDs := make(map[int64]int32, len(triangles)*3/2)
+	for _, t := range triangles {
+		for k, id := range t.EdgeIDs {
+			edgeTris[id] = append(edgeTris[id], t)
+			edgeIDs[GenEdgeKey(t.Vertices[k].Index, t.Vertices[(k+1)%3].Index)] = id
+		}
+	}
+
+	merged := make(map[*Triangle]bool, len(triangles))
+	convexes := make([]*Convex, 0, len(triangles))
+	var errs []error
+	for _, t := range triangles {
+		if merged[t] {
+			continue
+		}
+		merged[t] = true
+		c := NewConvex(t, int32(len(convexes)))
+		// 当前凸多边形的边界边与顶点集合
+		boundary := slices.Clone(t.EdgeIDs)
+		vertices := map[int32]bool{}
+		for _, v := range t.Vertices {
+			vertices[v.Index] = true
+		}
+
+		for changed := true; changed; {
+			changed = false
+			for _, id := range boundary {
+				if !passable(id) {
+					continue
+				}
+				n := mergeCandidate(edgeTris[id], merged, boundary, vertices)
+				if n == nil {
+					continue
+				}
+				p1, p2, p3 := splitSharedEdge(n, id)
+				if !c.MergeTriangle(p1, p2, []Vertice{p3}) {
+					continue
+				}
+				merged[n] = true
+				c.MergeTriangles = append(c.MergeTriangles, n)
+				vertices[p3.Index] = true
+				boundary = slices.DeleteFunc(boundary, func(e int32) bool { return e == id })
+				for _, e := range n.EdgeIDs {
+					if e != id {
+						boundary = append(boundary, e)
+					}
+				}
+				changed = true
+				break
+			}
+		}
+
+		// 统一为逆时针并按顶点顺序重建 EdgeIDs
+		coords := make([]Coord, len(c.Vertices))
+		for i, v := range c.Vertices {
+			coords[i] = v.Coord
+		}
+		if signedArea2(coords) < 0 {
+			slices.Reverse(c.Vertices)
+		}
+		num := len(c.Vertices)
+		c.EdgeIDs = make([]int32, num)
+		for i := range num {
+			a, b := c.Vertices[i].Index, c.Vertices[(i+1)%num].Index
+			id, ok := edgeIDs[GenEdgeKey(a, b)]
+			if !ok {
+				id = -1
+				errs = append(errs, fmt.Errorf("convex %d: no edge id for vertices %d-%d", c.Index, a, b))
+			}
+			c.EdgeIDs[i] = id
+		}
+
+		if err := c.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("convex %d: %w", c.Index, err))
+		}
+		convexes = append(convexes, c)
+	}
+	return convexes, errors.Join(errs...)
+}
+
+// mergeCandidate 从共享某条边界边的三角形中挑选可被吸纳的三角形。
+// 候选三角形须尚未合并、仅与当前凸多边形共享这一条边界边，
+// 且其第三个顶点不在凸多边形上，否则合并会产生重复顶点或内部顶点。
+func mergeCandidate(tris []*Triangle, merged map[*Triangle]bool, boundary []int32, vertices map[int32]bool) *Triangle {
+	for _, n := range tris {
+		if merged[n] {
+			continue
+		}
+		shared := 0
+		for _, e := range n.EdgeIDs {
+			if slices.Contains(boundary, e) {
+				shared++
+			}
+		}
+		if shared != 1 {
+			continue
+		}
+		for _, v := range n.Vertices {
+			if !vertices[v.Index] {
+				return n
+			}
+		}
+	}
+	return nil
+}
+
+// splitSharedEdge 返回三角形在指定边上的两个顶点以及不在该边上的第三个顶点。
+func splitSharedEdge(t *Triangle, edgeID int32) (p1, p2, p3 Vertice) {
+	for k, id := range t.EdgeIDs {
+		if id == edgeID {
+			return t.Vertices[k], t.Vertices[(k+1)%3], t.Vertices[(k+2)%3]
+		}
+	}
+	return t.Vertices[0], t.Vertices[1], t.Vertices[2]
+}
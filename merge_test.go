@@ -0,0 +1,100 @@
+package geo
+
+import "testing"
+
+func TestMergeTrianglesToConvexes(t *testing.T) {
+	square := []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	var triHoles [][]Coord
+	for i := range int32(3) {
+		for j := range int32(3) {
+			x, z := 100+i*300, 100+j*300
+			triHoles = append(triHoles, []Coord{{x, z}, {x + 100, z}, {x + 50, z + 100}})
+		}
+	}
+	tests := []struct {
+		name  string
+		outer []Coord
+		holes [][]Coord
+		block bool // 将全部内部边标记为不可通行
+		min   int  // 期望的凸多边形数量下限
+		max   int  // 期望的凸多边形数量上限，-1 表示须少于三角形数量
+	}{
+		{name: "square merges into one convex", outer: square, min: 1, max: 1},
+		{name: "blocked edges are not crossed", outer: square, block: true, min: 2, max: 2},
+		{name: "hexagon merges into one convex", outer: []Coord{{0, 0}, {100, 0}, {150, 80}, {100, 160}, {0, 160}, {-50, 80}}, min: 1, max: 1},
+		// 贪心合并不保证最优，但至多为最优解（2 个）的 4 倍，且三角形数量仅为 4
+		{name: "L shape splits at the reflex vertex", outer: []Coord{{0, 0}, {200, 0}, {200, 100}, {100, 100}, {100, 200}, {0, 200}}, min: 2, max: 3},
+		{name: "triangular holes", outer: []Coord{{0, 0}, {1000, 0}, {1000, 1000}, {0, 1000}}, holes: triHoles, min: 1, max: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewNavMeshBuilder(tt.outer, tt.holes...).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if tt.block {
+				for _, e := range m.Edges {
+					e.IsAdjacency = false
+				}
+			}
+			cs, err := m.MergeConvexes()
+			if err != nil {
+				t.Fatalf("MergeConvexes() error = %v", err)
+			}
+			if len(cs) < tt.min || (tt.max >= 0 && len(cs) > tt.max) {
+				t.Fatalf("len(convexes) = %d, want in [%d, %d]", len(cs), tt.min, tt.max)
+			}
+			if tt.max < 0 && len(cs) >= len(m.Triangles) {
+				t.Fatalf("len(convexes) = %d, want fewer than %d triangles", len(cs), len(m.Triangles))
+			}
+			// 未标记阻挡边时，仅凭三角形推导邻接关系与按边表判断的结果一致
+			if plain := MergeTrianglesToConvexes(m.Triangles); !tt.block && len(plain) != len(cs) {
+				t.Fatalf("MergeTrianglesToConvexes() returned %d convexes, MergeConvexes() %d", len(plain), len(cs))
+			}
+			seen := map[*Triangle]int{}
+			for i, c := range cs {
+				if c.Index != int32(i) {
+					t.Fatalf("convex %d has Index %d", i, c.Index)
+				}
+				for _, tri := range c.MergeTriangles {
+					seen[tri]++
+				}
+				coords := make([]Coord, len(c.Vertices))
+				for k, v := range c.Vertices {
+					coords[k] = v.Coord
+				}
+				if signedArea2(coords) <= 0 {
+					t.Fatalf("convex %d is not counter-clockwise", i)
+				}
+				n := len(c.Vertices)
+				for k, id := range c.EdgeIDs {
+					e := m.GetEdge(id)
+					if e == nil || GenEdgeKey(e.Vertices[0].Index, e.Vertices[1].Index) != GenEdgeKey(c.Vertices[k].Index, c.Vertices[(k+1)%n].Index) {
+						t.Fatalf("convex %d edge %d does not match its vertices", i, k)
+					}
+				}
+			}
+			if len(seen) != len(m.Triangles) {
+				t.Fatalf("convexes cover %d triangles, want %d", len(seen), len(m.Triangles))
+			}
+			for tri, n := range seen {
+				if n != 1 {
+					t.Fatalf("triangle %d merged %d times", tri.Index, n)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeTrianglesToConvexesMissingEdgeIDs(t *testing.T) {
+	tri := &Triangle{Vertices: []Vertice{{0, Coord{0, 0}}, {1, Coord{10, 0}}, {2, Coord{0, 10}}}}
+	tri.CalCenter()
+	cs := MergeTrianglesToConvexes([]*Triangle{tri})
+	if len(cs) != 1 || cs[0].EdgeIDs[0] != -1 {
+		t.Fatalf("convexes = %v, want one convex with EdgeIDs[0] = -1", cs)
+	}
+	m := &NavMesh{Triangles: []*Triangle{tri}}
+	if _, err := m.MergeConvexes(); err == nil {
+		t.Fatal("MergeConvexes() error = nil, want missing edge id error")
+	}
+}
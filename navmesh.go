@@ -0,0 +1,220 @@
+package geo
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	// ErrInvalidRing 表示环形顶点序列在去重后不足 3 个顶点或面积为 0
+	ErrInvalidRing = errors.New("geo: ring needs at least 3 distinct vertices and a non-zero area")
+	// ErrHoleOutside 表示洞不在外边界内部（或位于另一个洞内）
+	ErrHoleOutside = errors.New("geo: hole is not inside the outer boundary")
+	// ErrTriangulate 表示无法完成三角剖分（通常是输入的环自相交或相互交叠）
+	ErrTriangulate = errors.New("geo: failed to triangulate polygon")
+)
+
+// NavMesh 表示由可通行区域剖分得到的三角形导航网格。
+// Edges 以边序号为下标存储，Triangle.EdgeIDs 中的值即为该切片的下标；
+// 三角形的第 k 条边连接 Vertices[k] 与 Vertices[(k+1)%3]，与 GetEdgeMidCoords 的顺序一致。
+type NavMesh struct {
+	Vertices  []Vertice   // 全部顶点，以顶点序号为下标
+	Triangles []*Triangle // 全部三角形，以三角形序号为下标，顶点均为逆时针排列
+	Edges     []*Edge     // 全部边，以边序号为下标
+
+	edgeIndex map[int64]int32 // GenEdgeKey → 边序号
+}
+
+// GetEdge 根据边序号返回对应的边，序号越界时返回 nil。
+func (m *NavMesh) GetEdge(id int32) *Edge {
+	if id < 0 || int(id) >= len(m.Edges) {
+		return nil
+	}
+	return m.Edges[id]
+}
+
+// FindEdge 根据两个顶点序号查找连接它们的边及其序号，与顶点顺序无关。
+func (m *NavMesh) FindEdge(i, j int32) (*Edge, int32, bool) {
+	id, ok := m.edgeIndex[GenEdgeKey(i, j)]
+	if !ok {
+		return nil, -1, false
+	}
+	return m.Edges[id], id, true
+}
+
+// NavMeshBuilder 将可通行多边形（外边界 + 障碍洞）剖分为约束 Delaunay 三角导航网格。
+// 外边界与洞的环边作为约束边保留在结果中，成为不可通行的障碍边（IsAdjacency 为 false）；
+// 内部边均由两个三角形共享，成为可通行的邻接边。
+type NavMeshBuilder struct {
+	outer []Coord
+	holes [][]Coord
+}
+
+// NewNavMeshBuilder 以外边界环和若干障碍洞环创建导航网格构建器。
+// 环的方向与是否首尾闭合均无要求，构建时会自动统一为外边界逆时针、洞顺时针。
+func NewNavMeshBuilder(outer []Coord, holes ...[]Coord) *NavMeshBuilder {
+	return &NavMeshBuilder{
+		outer: outer,
+		holes: holes,
+	}
+}
+
+// AddHole 追加一个障碍洞环。
+func (b *NavMeshBuilder) AddHole(hole []Coord) {
+	b.holes = append(b.holes, hole)
+}
+
+// Build 执行剖分并生成导航网格。
+// 流程分为五步：
+//  1. 统一环方向，并为坐标相同的顶点分配同一个全局顶点序号；
+//  2. 对全部顶点构造 Delaunay 三角剖分；
+//  3. 将外环与洞环的每条边作为约束边插入，再以约束执行 Lawson 翻转得到约束 Delaunay 三角剖分；
+//  4. 去掉外边界以外与洞内的三角形；
+//  5. 生成三角形、边表及邻接关系，并通过 CalCenter 预计算重心。
+func (b *NavMeshBuilder) Build() (*NavMesh, error) {
+	outer := normalizeRing(b.outer, true)
+	if outer == nil {
+		return nil, ErrInvalidRing
+	}
+	holes := make([][]Coord, 0, len(b.holes))
+	for _, h := range b.holes {
+		hole := normalizeRing(h, false)
+		if hole == nil {
+			return nil, ErrInvalidRing
+		}
+		holes = append(holes, hole)
+	}
+
+	coords, ring, holeRings := indexRings(outer, holes)
+	tris := triangulatePoints(coords)
+	if tris == nil {
+		return nil, ErrTriangulate
+	}
+	t := newTriangulation(coords, tris)
+	t.flipToDelaunay(nil)
+
+	constraints := make(map[int64]bool, len(coords))
+	var segments [][2]int32
+	for _, r := range append([][]int32{ring}, holeRings...) {
+		for i := range r {
+			s, err := t.insertConstraint(r[i], r[(i+1)%len(r)], constraints)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, s...)
+		}
+	}
+	t.flipToDelaunay(constraints)
+
+	tris, err := t.interior(constraints, segments)
+	if err != nil {
+		return nil, err
+	}
+	return newNavMesh(coords, tris), nil
+}
+
+// normalizeRing 清理环形顶点序列并统一其方向。
+// 移除连续重复点及与起点重复的闭合点，再根据有向面积判断方向：
+// ccw 为 true 时输出逆时针环（外边界），否则输出顺时针环（洞）。
+// 清理后不足 3 个顶点或面积为 0 的环视为无效，返回 nil。
+func normalizeRing(ring []Coord, ccw bool) []Coord {
+	ret := make([]Coord, 0, len(ring))
+	for _, c := range ring {
+		if len(ret) > 0 && ret[len(ret)-1] == c {
+			continue
+		}
+		ret = append(ret, c)
+	}
+	for len(ret) > 1 && ret[0] == ret[len(ret)-1] {
+		ret = ret[:len(ret)-1]
+	}
+	if len(ret) < 3 {
+		return nil
+	}
+	area := signedArea2(ret)
+	if area == 0 {
+		return nil
+	}
+	if (area > 0) != ccw {
+		slices.Reverse(ret)
+	}
+	return ret
+}
+
+// signedArea2 通过 Shoelace 公式计算环的两倍有向面积，正值表示逆时针。
+// 使用 float64 累加，避免大坐标下 int64 中间值溢出。
+func signedArea2(ring []Coord) float64 {
+	var s float64
+	n := len(ring)
+	for i := range n {
+		a := ring[i]
+		b := ring[(i+1)%n]
+		s += float64(a.X)*float64(b.Z) - float64(b.X)*float64(a.Z)
+	}
+	return s
+}
+
+// indexRings 为所有环上的顶点分配全局顶点序号，坐标相同的顶点共享同一序号。
+// 返回以序号为下标的坐标表，以及以序号描述的外环和洞环。
+func indexRings(outer []Coord, holes [][]Coord) ([]Coord, []int32, [][]int32) {
+	coords := make([]Coord, 0, len(outer))
+	index := make(map[Coord]int32, len(outer))
+	toIndex := func(r []Coord) []int32 {
+		ids := make([]int32, len(r))
+		for i, c := range r {
+			id, ok := index[c]
+			if !ok {
+				id = int32(len(coords))
+				index[c] = id
+				coords = append(coords, c)
+			}
+			ids[i] = id
+		}
+		return ids
+	}
+	ring := toIndex(outer)
+	holeRings := make([][]int32, len(holes))
+	for i, h := range holes {
+		holeRings[i] = toIndex(h)
+	}
+	return coords, ring, holeRings
+}
+
+// newNavMesh 根据坐标表和以顶点序号描述的逆时针三角形生成导航网格。
+// 边序号按三角形及其边的遍历顺序依次分配；被两个三角形共享的边标记为邻接边。
+func newNavMesh(coords []Coord, tris [][3]int32) *NavMesh {
+	m := &NavMesh{
+		Vertices:  make([]Vertice, len(coords)),
+		Triangles: make([]*Triangle, 0, len(tris)),
+		edgeIndex: make(map[int64]int32, len(tris)*3/2+1),
+	}
+	for i, c := range coords {
+		m.Vertices[i] = Vertice{Index: int32(i), Coord: c}
+	}
+
+	for ti, tri := range tris {
+		t := &Triangle{
+			Index:    int32(ti),
+			Vertices: []Vertice{m.Vertices[tri[0]], m.Vertices[tri[1]], m.Vertices[tri[2]]},
+			EdgeIDs:  make([]int32, 3),
+		}
+		for k := range 3 {
+			v0 := m.Vertices[tri[k]]
+			v1 := m.Vertices[tri[(k+1)%3]]
+			key := GenEdgeKey(v0.Index, v1.Index)
+			id, ok := m.edgeIndex[key]
+			if !ok {
+				id = int32(len(m.Edges))
+				m.edgeIndex[key] = id
+				m.Edges = append(m.Edges, &Edge{Vertices: [2]Vertice{v0, v1}})
+			}
+			e := m.Edges[id]
+			e.AdjacenctTriangles = append(e.AdjacenctTriangles, t)
+			e.IsAdjacency = len(e.AdjacenctTriangles) == 2
+			t.EdgeIDs[k] = id
+		}
+		t.CalCenter()
+		m.Triangles = append(m.Triangles, t)
+	}
+	return m
+}
@@ -0,0 +1,180 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNavMeshBuilderBuild(t *testing.T) {
+	square := func(x, z, w int32) []Coord {
+		return []Coord{{x, z}, {x + w, z}, {x + w, z + w}, {x, z + w}}
+	}
+	var gridHoles [][]Coord
+	for i := range int32(4) {
+		for j := range int32(4) {
+			gridHoles = append(gridHoles, square(100+i*220, 100+j*220, 100))
+		}
+	}
+	tests := []struct {
+		name     string
+		outer    []Coord
+		holes    [][]Coord
+		area2    int64 // 可通行区域面积的两倍
+		boundary int   // 约束边（障碍边）数量
+	}{
+		{
+			name:     "convex",
+			outer:    square(0, 0, 100),
+			area2:    2 * 10000,
+			boundary: 4,
+		},
+		{
+			name:     "square hole",
+			outer:    square(0, 0, 100),
+			holes:    [][]Coord{square(40, 40, 20)},
+			area2:    2 * (10000 - 400),
+			boundary: 8,
+		},
+		{
+			name:     "clockwise outer with collinear vertices and closed ring",
+			outer:    []Coord{{0, 0}, {0, 500}, {0, 1000}, {500, 1000}, {1000, 1000}, {1000, 500}, {1000, 0}, {500, 0}, {0, 0}},
+			area2:    2 * 1000 * 1000,
+			boundary: 8,
+		},
+		{
+			name:     "many holes",
+			outer:    square(0, 0, 1000),
+			holes:    gridHoles,
+			area2:    2 * (1000*1000 - 16*10000),
+			boundary: 4 + 16*4,
+		},
+		{
+			name:     "concave L shape",
+			outer:    []Coord{{0, 0}, {200, 0}, {200, 100}, {100, 100}, {100, 200}, {0, 200}},
+			area2:    2 * 30000,
+			boundary: 6,
+		},
+		{
+			name:     "concave star with hole",
+			outer:    starRing(12, 1000, 400),
+			holes:    [][]Coord{square(-50, -50, 100)},
+			area2:    int64(signedArea2(starRing(12, 1000, 400))) - 2*10000,
+			boundary: 24 + 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewNavMeshBuilder(tt.outer, tt.holes...).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			checkNavMesh(t, m, tt.area2, tt.boundary)
+		})
+	}
+}
+
+func TestNavMeshBuilderInvalidRing(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer []Coord
+		holes [][]Coord
+	}{
+		{"too few vertices", []Coord{{0, 0}, {10, 0}}, nil},
+		{"duplicate vertices", []Coord{{0, 0}, {0, 0}, {10, 0}, {10, 0}}, nil},
+		{"collinear", []Coord{{0, 0}, {10, 0}, {20, 0}}, nil},
+		{"degenerate hole", []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}, [][]Coord{{{10, 10}, {20, 20}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNavMeshBuilder(tt.outer, tt.holes...).Build(); !errors.Is(err, ErrInvalidRing) {
+				t.Fatalf("Build() error = %v, want ErrInvalidRing", err)
+			}
+		})
+	}
+}
+
+func TestNavMeshBuilderInvalidLayout(t *testing.T) {
+	outer := []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	tests := []struct {
+		name  string
+		outer []Coord
+		holes [][]Coord
+		want  error
+	}{
+		{"hole outside", outer, [][]Coord{{{200, 10}, {220, 10}, {220, 30}, {200, 30}}}, ErrHoleOutside},
+		{"hole inside hole", outer, [][]Coord{
+			{{10, 10}, {90, 10}, {90, 90}, {10, 90}},
+			{{40, 40}, {60, 40}, {60, 60}, {40, 60}},
+		}, ErrHoleOutside},
+		{"hole crossing outer", outer, [][]Coord{{{80, 40}, {120, 40}, {120, 60}, {80, 60}}}, ErrTriangulate},
+		{"self-intersecting outer", []Coord{{0, 0}, {100, 100}, {100, 0}, {0, 100}, {-50, 50}}, nil, ErrTriangulate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNavMeshBuilder(tt.outer, tt.holes...).Build(); !errors.Is(err, tt.want) {
+				t.Fatalf("Build() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// starRing 返回以原点为中心、n 个尖角的逆时针星形环，外径 outer、内径 inner。
+func starRing(n int, outer, inner float64) []Coord {
+	ring := make([]Coord, 0, 2*n)
+	for i := range 2 * n {
+		r := outer
+		if i%2 == 1 {
+			r = inner
+		}
+		a := float64(i) * math.Pi / float64(n)
+		ring = append(ring, Coord{X: int32(math.Round(r * math.Cos(a))), Z: int32(math.Round(r * math.Sin(a)))})
+	}
+	return ring
+}
+
+// checkNavMesh 校验导航网格：三角形均为逆时针且面积之和正确，边表与三角形边顺序一致，
+// 障碍边只属于一个三角形，可通行边满足局部 Delaunay 条件。
+func checkNavMesh(t *testing.T, m *NavMesh, area2 int64, boundary int) {
+	t.Helper()
+	var sum int64
+	for _, tri := range m.Triangles {
+		a := cross(tri.Vertices[1].Coord, tri.Vertices[2].Coord, tri.Vertices[0].Coord)
+		if a <= 0 {
+			t.Fatalf("triangle %d is not counter-clockwise: %v", tri.Index, tri.Vertices)
+		}
+		sum += int64(signedArea2([]Coord{tri.Vertices[0].Coord, tri.Vertices[1].Coord, tri.Vertices[2].Coord}))
+		for k, id := range tri.EdgeIDs {
+			e := m.GetEdge(id)
+			if e == nil || GenEdgeKey(e.Vertices[0].Index, e.Vertices[1].Index) != GenEdgeKey(tri.Vertices[k].Index, tri.Vertices[(k+1)%3].Index) {
+				t.Fatalf("triangle %d edge %d does not connect vertices %d and %d", tri.Index, k, k, (k+1)%3)
+			}
+		}
+	}
+	if sum != area2 {
+		t.Fatalf("area2 = %d, want %d", sum, area2)
+	}
+	n := 0
+	for _, e := range m.Edges {
+		switch {
+		case !e.IsAdjacency:
+			n++
+			if len(e.AdjacenctTriangles) != 1 {
+				t.Fatalf("boundary edge %v has %d triangles", e.Vertices, len(e.AdjacenctTriangles))
+			}
+		case len(e.AdjacenctTriangles) != 2:
+			t.Fatalf("adjacency edge %v has %d triangles", e.Vertices, len(e.AdjacenctTriangles))
+		default:
+			t1, t2 := e.AdjacenctTriangles[0], e.AdjacenctTriangles[1]
+			for _, v := range t2.Vertices {
+				if v.Index != e.Vertices[0].Index && v.Index != e.Vertices[1].Index &&
+					InCircle(t1.Vertices[0].Coord, t1.Vertices[1].Coord, t1.Vertices[2].Coord, v.Coord) > 0 {
+					t.Fatalf("edge %v is not locally Delaunay", e.Vertices)
+				}
+			}
+		}
+	}
+	if n != boundary {
+		t.Fatalf("boundary edges = %d, want %d", n, boundary)
+	}
+}
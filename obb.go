@@ -0,0 +1,137 @@
+package geo
+
+// OrientedRect 表示有向包围盒（OBB），即可任意旋转的矩形。
+// 由中心点、沿朝向的半长 HalfLength、垂直于朝向的半宽 HalfWidth 及朝向向量 Facing 定义，
+// 适合表示旋转的建筑物占地与直线型（光束、冲锋）技能的判定范围。
+type OrientedRect struct {
+	Center     Coord
+	HalfLength int32  // 沿朝向方向的半长
+	HalfWidth  int32  // 垂直于朝向方向的半宽
+	Facing     Vector // 朝向向量，只取方向，零向量视为 X 轴正方向
+}
+
+// NewOrientedRect 以中心点、半长、半宽与朝向创建有向包围盒。
+func NewOrientedRect(center Coord, halfLength, halfWidth int32, facing Vector) OrientedRect {
+	return OrientedRect{
+		Center:     center,
+		HalfLength: halfLength,
+		HalfWidth:  halfWidth,
+		Facing:     facing,
+	}
+}
+
+// FromSegment 以线段为中轴、halfWidth 为半宽创建有向包围盒，用于"粗线段"类技能判定。
+// 中心取线段中点，朝向取 A→B 方向，半长向上取整以保证盒子覆盖整条线段；
+// 顶点由中轴经 Segment.Pan 向两侧平移得到（见 GetVectors）。
+func FromSegment(seg Segment, halfWidth int32) OrientedRect {
+	v := seg.ToVector()
+	return OrientedRect{
+		Center:     CalMidCoord(seg.A, seg.B),
+		HalfLength: RoundCeil.Round(v.Length() / 2),
+		HalfWidth:  halfWidth,
+		Facing:     v,
+	}
+}
+
+// Spine 返回有向包围盒沿朝向方向的中轴线段，从尾部指向头部。
+func (o *OrientedRect) Spine() Segment {
+	half := o.scaledFacing(o.HalfLength)
+	back := Vector{X: -half.X, Z: -half.Z}
+	return NewSegment(back.ToCoord(o.Center), half.ToCoord(o.Center))
+}
+
+// GetVectors 返回有向包围盒按逆时针排列的四个顶点位置向量（右后→右前→左前→左后）。
+// 中轴经 Pan 向左、右各平移 HalfWidth 得到两条长边，逆时针顺序可直接用于 Circle.IsInterPolygon。
+// 半长或半宽为 0（或取整后为 0）时盒子退化，重复的顶点会被移除：退化为线段时返回 2 个顶点，
+// 退化为点时返回 1 个顶点。退化盒子没有面积，IntersectConvex 等要求至少 3 个顶点的函数会直接返回 false，
+// 本类型的 IsCoordInside、IntersectSegment 与 IntersectOrientedRect 则按线段或点继续判定。
+func (o *OrientedRect) GetVectors() []Vector {
+	var corners []Coord
+	if spine := o.Spine(); spine.A == spine.B {
+		// 半长为 0 时中轴退化为点，Pan 无法确定法向，改用朝向的法向量平移
+		f := o.scaledFacing(o.HalfWidth)
+		n := Vector{X: -f.Z, Z: f.X}
+		left, right := n.ToCoord(o.Center), Vector{X: -n.X, Z: -n.Z}.ToCoord(o.Center)
+		corners = []Coord{right, right, left, left}
+	} else {
+		left := spine.Pan(o.HalfWidth, true)
+		right := spine.Pan(o.HalfWidth, false)
+		corners = []Coord{right.A, right.B, left.B, left.A}
+	}
+	corners = dedupeRing(corners)
+	vectors := make([]Vector, len(corners))
+	for i, c := range corners {
+		vectors[i] = NewVectorByCoord(c)
+	}
+	return vectors
+}
+
+// IsCoordInside 判断点是否在有向包围盒内（含边界），基于 GetVectors 的顶点做叉积判定。
+func (o *OrientedRect) IsCoordInside(p Coord) bool {
+	return isCoordInConvexVectors(o.GetVectors(), p)
+}
+
+// ToRect 返回有向包围盒四个顶点的轴对齐包围盒。
+func (o *OrientedRect) ToRect() (minX, minZ, maxX, maxZ int32) {
+	vectors := o.GetVectors()
+	minX, minZ = vectors[0].X, vectors[0].Z
+	maxX, maxZ = minX, minZ
+	for _, v := range vectors[1:] {
+		minX, minZ = min(minX, v.X), min(minZ, v.Z)
+		maxX, maxZ = max(maxX, v.X), max(maxZ, v.Z)
+	}
+	return
+}
+
+// GetLocationToBorder 获取有向包围盒的轴对齐包围盒与给定边界的象限重叠关系。
+func (o *OrientedRect) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := o.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// Rotate 返回绕中心点旋转指定弧度后的有向包围盒，左手坐标系下正角度为顺时针方向（与 Vector.Rotate 一致）。
+// 朝向先放大到长度 1000 再旋转，避免短朝向向量旋转后被截断为零向量。
+func (o *OrientedRect) Rotate(angle float64) OrientedRect {
+	facing := o.scaledFacing(1000)
+	ret := *o
+	ret.Facing = facing.Rotate(angle)
+	return ret
+}
+
+// IntersectOrientedRect 使用分离轴定理判断两个有向包围盒是否相交（含接触）。
+// 需要推离向量时可直接对两者的 GetVectors 调用 IntersectConvex（退化盒子除外，见 GetVectors）。
+// 任一盒子退化为线段或点时，改为判断另一个盒子与该线段（或点）是否相交。
+func (o *OrientedRect) IntersectOrientedRect(other *OrientedRect) bool {
+	a, b := o.GetVectors(), other.GetVectors()
+	switch {
+	case len(a) < 3:
+		return other.IntersectSegment(NewSegment(Coord(a[0]), Coord(a[len(a)-1])))
+	case len(b) < 3:
+		return o.IntersectSegment(NewSegment(Coord(b[0]), Coord(b[len(b)-1])))
+	}
+	hit, _, _ := IntersectConvex(a, b)
+	return hit
+}
+
+// IntersectSegment 判断有向包围盒与线段是否相交（含接触）：端点在盒内，或线段与任一条边相交。
+func (o *OrientedRect) IntersectSegment(s Segment) bool {
+	vectors := o.GetVectors()
+	if isCoordInConvexVectors(vectors, s.A) || isCoordInConvexVectors(vectors, s.B) {
+		return true
+	}
+	for i := range vectors {
+		if isSegmentCross(s.A, s.B, Coord(vectors[i]), Coord(vectors[(i+1)%len(vectors)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// scaledFacing 返回与朝向同向、长度为 length 的向量，朝向为零向量时取 X 轴正方向。
+func (o *OrientedRect) scaledFacing(length int32) Vector {
+	facingLength := o.Facing.Length()
+	if facingLength == 0 {
+		return Vector{X: length}
+	}
+	return o.Facing.Trunc(float64(length) / facingLength)
+}
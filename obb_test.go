@@ -0,0 +1,163 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestOrientedRectGetVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		o    OrientedRect
+		want []Coord // 顶点集合，顺序不限
+	}{
+		{"axis aligned", NewOrientedRect(Coord{0, 0}, 10, 5, Vector{1, 0}), []Coord{{-10, -5}, {10, -5}, {10, 5}, {-10, 5}}},
+		{"facing Z", NewOrientedRect(Coord{100, 100}, 10, 5, Vector{0, 3}), []Coord{{95, 90}, {105, 90}, {105, 110}, {95, 110}}},
+		{"zero facing is X", NewOrientedRect(Coord{0, 0}, 10, 5, Vector{}), []Coord{{-10, -5}, {10, -5}, {10, 5}, {-10, 5}}},
+		{"from segment", FromSegment(NewSegment(Coord{0, 0}, Coord{100, 0}), 5), []Coord{{0, -5}, {100, -5}, {100, 5}, {0, 5}}},
+		{"degenerate to segment", NewOrientedRect(Coord{0, 0}, 100, 0, Vector{1, 0}), []Coord{{-100, 0}, {100, 0}}},
+		{"degenerate to width segment", NewOrientedRect(Coord{0, 0}, 0, 10, Vector{1, 0}), []Coord{{0, -10}, {0, 10}}},
+		{"degenerate to point", NewOrientedRect(Coord{5, 5}, 0, 0, Vector{1, 0}), []Coord{{5, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := tt.o.GetVectors()
+			got := make([]Coord, len(vs))
+			for i, v := range vs {
+				got[i] = Coord(v)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetVectors() = %v, want %v", got, tt.want)
+			}
+			for _, c := range tt.want {
+				if !slices.Contains(got, c) {
+					t.Fatalf("GetVectors() = %v, want %v", got, tt.want)
+				}
+			}
+			if len(got) >= 3 && signedArea2(got) <= 0 {
+				t.Fatalf("GetVectors() = %v is not counter-clockwise", got)
+			}
+		})
+	}
+}
+
+func TestOrientedRectIsCoordInside(t *testing.T) {
+	box := NewOrientedRect(Coord{0, 0}, 10, 5, Vector{1, 0})
+	diagonal := FromSegment(NewSegment(Coord{0, 0}, Coord{100, 100}), 10)
+	line := NewOrientedRect(Coord{0, 0}, 100, 0, Vector{1, 0})
+	tests := []struct {
+		name string
+		o    OrientedRect
+		p    Coord
+		want bool
+	}{
+		{"box center", box, Coord{0, 0}, true},
+		{"box corner", box, Coord{10, 5}, true},
+		{"box outside length", box, Coord{11, 0}, false},
+		{"box outside width", box, Coord{0, 6}, false},
+		{"diagonal on spine", diagonal, Coord{50, 50}, true},
+		{"diagonal off to side", diagonal, Coord{50, 65}, false},
+		{"diagonal within width", diagonal, Coord{45, 55}, true},
+		{"diagonal beyond end", diagonal, Coord{110, 110}, false},
+		{"line on segment", line, Coord{50, 0}, true},
+		{"line off segment", line, Coord{50, 1}, false},
+		{"line beyond end", line, Coord{150, 0}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.IsCoordInside(tt.p); got != tt.want {
+				t.Fatalf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrientedRectRotate(t *testing.T) {
+	o := NewOrientedRect(Coord{10, 20}, 30, 5, Vector{1, 0})
+	tests := []struct {
+		name string
+		got  OrientedRect
+		want Vector
+	}{
+		{"quarter turn", o.Rotate(math.Pi / 2), Vector{0, 1000}},
+		{"half turn", o.Rotate(math.Pi), Vector{-1000, 0}},
+		{"fixed quarter turn", o.RotateFixed(AngleFromDegrees(90)), Vector{0, 1000}},
+		{"fixed negative quarter turn", o.RotateFixed(AngleFromDegrees(-90)), Vector{0, -1000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Facing != tt.want {
+				t.Fatalf("Facing = %v, want %v", tt.got.Facing, tt.want)
+			}
+			if tt.got.Center != o.Center || tt.got.HalfLength != o.HalfLength || tt.got.HalfWidth != o.HalfWidth {
+				t.Fatalf("rotation changed the box: %v", tt.got)
+			}
+		})
+	}
+}
+
+func TestOrientedRectIntersect(t *testing.T) {
+	line := NewOrientedRect(Coord{0, 0}, 100, 0, Vector{1, 0})
+	point := NewOrientedRect(Coord{5, 5}, 0, 0, Vector{1, 0})
+	box := NewOrientedRect(Coord{0, 0}, 10, 5, Vector{1, 0})
+	tests := []struct {
+		name string
+		a, b OrientedRect
+		want bool
+	}{
+		{"overlapping boxes", box, NewOrientedRect(Coord{15, 0}, 10, 5, Vector{1, 0}), true},
+		{"touching boxes", box, NewOrientedRect(Coord{20, 0}, 10, 5, Vector{1, 0}), true},
+		{"separated boxes", box, NewOrientedRect(Coord{21, 0}, 10, 5, Vector{1, 0}), false},
+		{"rotated cross", box, box.Rotate(math.Pi / 2), true},
+		{"rotated corner gap", box, NewOrientedRect(Coord{18, 11}, 5, 5, Vector{1, 1}), false},
+		{"line through box", line, NewOrientedRect(Coord{0, 50}, 10, 60, Vector{1, 0}), true},
+		{"box through line", NewOrientedRect(Coord{0, 50}, 10, 60, Vector{1, 0}), line, true},
+		{"line and far box", line, NewOrientedRect(Coord{300, 0}, 10, 10, Vector{1, 0}), false},
+		{"collinear lines apart", line, NewOrientedRect(Coord{300, 0}, 100, 0, Vector{1, 0}), false},
+		{"collinear lines touching", line, NewOrientedRect(Coord{200, 0}, 100, 0, Vector{1, 0}), true},
+		{"same point", point, NewOrientedRect(Coord{5, 5}, 0, 0, Vector{0, 1}), true},
+		{"point off line", point, line, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.IntersectOrientedRect(&tt.b); got != tt.want {
+				t.Fatalf("IntersectOrientedRect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOrientedRectFromSegment 校验随机线段生成的有向包围盒覆盖中轴两侧的点，且中轴与原线段一致。
+func TestOrientedRectFromSegment(t *testing.T) {
+	rng := rand.New(rand.NewPCG(11, 0))
+	for range 300 {
+		seg := NewSegment(Coord{rng.Int32N(200), rng.Int32N(200)}, Coord{rng.Int32N(200), rng.Int32N(200)})
+		if seg.A == seg.B {
+			continue
+		}
+		o := FromSegment(seg, 5+rng.Int32N(20))
+		if sp := o.Spine(); CalDstCoordToCoord(sp.A, seg.A) > 2 || CalDstCoordToCoord(sp.B, seg.B) > 2 {
+			t.Fatalf("Spine() = %v, want close to %v", sp, seg)
+		}
+		l := o.Facing.Length()
+		fx, fz := float64(o.Facing.X)/l, float64(o.Facing.Z)/l
+		x0, z0, x1, z1 := o.ToRect()
+		for range 50 {
+			u := (rng.Float64()*2 - 1) * float64(o.HalfLength-3)
+			w := (rng.Float64()*2 - 1) * float64(o.HalfWidth-3)
+			p := Coord{o.Center.X + int32(math.Round(u*fx-w*fz)), o.Center.Z + int32(math.Round(u*fz+w*fx))}
+			if !o.IsCoordInside(p) {
+				t.Fatalf("%v IsCoordInside(%v) = false", o, p)
+			}
+			if p.X < x0 || p.X > x1 || p.Z < z0 || p.Z > z1 {
+				t.Fatalf("%v ToRect() misses %v", o, p)
+			}
+		}
+		cross := NewSegment(Coord{o.Center.X - 300, o.Center.Z - 300}, Coord{o.Center.X + 300, o.Center.Z + 300})
+		if !o.IntersectSegment(cross) {
+			t.Fatalf("%v IntersectSegment(%v) = false", o, cross)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package geo
+
+import "math"
+
+// JoinType 表示多边形偏移时凸角处的连接方式。
+type JoinType int8
+
+const (
+	JoinMiter  JoinType = iota // 斜接：延长两条偏移边至相交，尖角过长时退化为 JoinSquare
+	JoinRound                  // 圆角：以原顶点为圆心、偏移距离为半径的圆弧连接
+	JoinSquare                 // 方角：在距原顶点偏移距离处垂直于角平分线截断
+)
+
+// offsetMiterLimit 为斜接长度（原顶点到斜接点的距离）与偏移距离之比的上限，超过时改用方角。
+const offsetMiterLimit = 2.0
+
+// Offset 将环整体向外（delta > 0，膨胀）或向内（delta < 0，收缩）偏移 delta 距离，
+// 用于构建导航网格前按单位半径膨胀障碍，以及计算安全区的收缩边界。环的方向不限。
+//
+// 与 Clipper 的做法一致：每条边沿外法向平移得到偏移边，偏移一侧为凸角的顶点按 join 补齐连接，
+// 另一侧的顶点依次连接两条偏移边端点与原顶点；得到的原始偏移路径可能自相交，
+// 再按正环绕数规则（环绕数大于 0 的区域）做一次叠加运算清理，因此凹多边形的结果同样是无自交的简单环。
+// 圆角由 GetArcCoords 采样，偏移点按四舍五入取整。
+//
+// 收缩时区域可能分裂为多个部分或完全消失，膨胀时凹口可能闭合形成洞，因此结果为多个环：
+// 外环逆时针、洞顺时针，每个洞紧随其所属外环之后。环无效时返回 nil，delta 为 0 时返回清理后的原环。
+func Offset(ring []Coord, delta int32, join JoinType) [][]Coord {
+	var rings [][]Coord
+	for _, p := range offsetRings([][]Coord{normalizeRing(ring, true)}, delta, join) {
+		rings = append(rings, p.Outer)
+		rings = append(rings, p.Holes...)
+	}
+	return rings
+}
+
+// Offset 将多边形整体偏移 delta 距离，外环与洞一同偏移（膨胀时洞随之缩小），见 Offset 函数。
+// 结果多边形的外环逆时针、洞顺时针，Index 按输出顺序从 0 开始。
+func (s *SimplePolygon) Offset(delta int32, join JoinType) []*SimplePolygon {
+	return offsetRings(booleanRings([]*SimplePolygon{s}), delta, join)
+}
+
+// offsetRings 偏移一组已统一方向（外环逆时针、洞顺时针）的环，并以正环绕数规则清理结果。
+func offsetRings(rings [][]Coord, delta int32, join JoinType) []*SimplePolygon {
+	var paths [][]Coord
+	for _, ring := range rings {
+		if len(ring) < 3 {
+			continue
+		}
+		if delta != 0 {
+			ring = offsetPath(ring, float64(delta), join)
+		}
+		if ring = dedupeRing(ring); len(ring) >= 3 {
+			paths = append(paths, ring)
+		}
+	}
+	return overlay([][][]Coord{paths}, func(wn []int) bool {
+		return wn[0] > 0
+	})
+}
+
+// offsetPath 生成单个环的原始偏移路径（可能自相交）。
+// 边 a→b 的外法向取右法向 (dz, -dx)：逆时针外环的右侧为外部，顺时针洞环的右侧为洞内，
+// 因此 delta > 0 时二者都使区域膨胀。
+func offsetPath(ring []Coord, delta float64, join JoinType) []Coord {
+	n := len(ring)
+	normals := make([][2]float64, n)
+	for i := range n {
+		a, b := ring[i], ring[(i+1)%n]
+		dx, dz := float64(b.X)-float64(a.X), float64(b.Z)-float64(a.Z)
+		length := math.Hypot(dx, dz)
+		normals[i] = [2]float64{dz / length, -dx / length}
+	}
+
+	path := make([]Coord, 0, n*2)
+	for i, v := range ring {
+		n1, n2 := normals[(i-1+n)%n], normals[i]
+		p1, p2 := offsetCoord(v, n1, delta), offsetCoord(v, n2, delta)
+		cos := n1[0]*n2[0] + n1[1]*n2[1]
+		sin := n1[0]*n2[1] - n1[1]*n2[0]
+		if sin == 0 && cos > 0 {
+			// 共线顶点无需连接
+			path = append(path, p1)
+			continue
+		}
+		// theta 为从 n1 到 n2 的有向转角；折返顶点（转角 π）两侧都需要连接，方向与 delta 一致
+		theta := math.Atan2(sin, cos)
+		if sin == 0 {
+			theta = math.Copysign(math.Pi, delta)
+		}
+		if theta*delta < 0 {
+			// 偏移一侧为凹角：原顶点参与连接，产生的反向小环由正环绕数规则清理
+			path = append(path, p1, v, p2)
+			continue
+		}
+		switch {
+		case join == JoinRound:
+			arc := GetArcCoords(p1, v, -theta)
+			path = append(path, p1)
+			path = append(path, arc[1:len(arc)-1]...)
+			path = append(path, p2)
+		case join == JoinMiter && 1+cos >= 2/(offsetMiterLimit*offsetMiterLimit):
+			scale := delta / (1 + cos)
+			path = append(path, offsetCoord(v, [2]float64{n1[0] + n2[0], n1[1] + n2[1]}, scale))
+		default:
+			path = append(path, squareJoin(v, n1, n2, delta)...)
+		}
+	}
+	return path
+}
+
+// squareJoin 计算方角连接：在距原顶点 |delta| 处作垂直于偏移方向角平分线的截断线，
+// 返回其与两条偏移边的交点。折返顶点的角平分线取前一条边的方向。
+func squareJoin(v Coord, n1, n2 [2]float64, delta float64) []Coord {
+	// 两条边的方向由法向逆时针旋转 90° 得到
+	d1 := [2]float64{-n1[1], n1[0]}
+	d2 := [2]float64{-n2[1], n2[0]}
+	wx, wz := delta*(n1[0]+n2[0]), delta*(n1[1]+n2[1])
+	if length := math.Hypot(wx, wz); length > 1e-9 {
+		wx, wz = wx/length, wz/length
+	} else {
+		wx, wz = d1[0], d1[1]
+	}
+	dist := math.Abs(delta)
+	point := func(n, d [2]float64) Coord {
+		// 偏移边 v + delta·n + s·d 与截断线 (x - v)·w = |delta| 的交点
+		s := (dist - delta*(n[0]*wx+n[1]*wz)) / (d[0]*wx + d[1]*wz)
+		return Coord{
+			X: addInt32(v.X, roundInt32(delta*n[0]+s*d[0])),
+			Z: addInt32(v.Z, roundInt32(delta*n[1]+s*d[1])),
+		}
+	}
+	return []Coord{point(n1, d1), point(n2, d2)}
+}
+
+// offsetCoord 返回 v 沿方向 n 移动 scale 倍后的坐标，偏移量按包级取整方式 Rounding 取整。
+func offsetCoord(v Coord, n [2]float64, scale float64) Coord {
+	return Coord{
+		X: addInt32(v.X, roundInt32(n[0]*scale)),
+		Z: addInt32(v.Z, roundInt32(n[1]*scale)),
+	}
+}
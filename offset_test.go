@@ -0,0 +1,153 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// ringsArea 返回各环有向面积之和，洞为负值。
+func ringsArea(rings [][]Coord) float64 {
+	var area float64
+	for _, r := range rings {
+		area += signedArea2(r) / 2
+	}
+	return area
+}
+
+func TestOffset(t *testing.T) {
+	square := []Coord{{0, 0}, {0, 100}, {100, 100}, {100, 0}} // 顺时针输入
+	u := []Coord{{0, 0}, {100, 0}, {100, 100}, {70, 100}, {70, 30}, {30, 30}, {30, 100}, {0, 100}}
+	c := []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}, {0, 55}, {80, 55}, {80, 20}, {20, 20}, {20, 45}, {0, 45}}
+	tests := []struct {
+		name    string
+		ring    []Coord
+		delta   int32
+		join    JoinType
+		rings   int
+		area    float64
+		epsilon float64
+		in, out []Coord
+	}{
+		{"miter inflate", square, 10, JoinMiter, 1, 120 * 120, 0, []Coord{{-10, -10}, {110, 50}}, []Coord{{-11, 50}}},
+		{"square inflate", square, 10, JoinSquare, 1, 14000 + 4*(100-(10*math.Sqrt2-10)*(10*math.Sqrt2-10)), 10, []Coord{{-10, 0}, {-5, -5}}, []Coord{{-9, -9}}},
+		{"round inflate", square, 10, JoinRound, 1, 14000 + math.Pi*100, 60, []Coord{{-10, 50}, {-6, -6}}, []Coord{{-8, -8}}},
+		{"miter deflate", square, -10, JoinMiter, 1, 80 * 80, 0, []Coord{{10, 10}, {90, 90}}, []Coord{{9, 50}}},
+		{"deflate to nothing", square, -60, JoinRound, 0, 0, 0, nil, []Coord{{50, 50}}},
+		{"zero delta", square, 0, JoinMiter, 1, 100 * 100, 0, []Coord{{0, 0}}, []Coord{{-1, 0}}},
+		{"U inflate miter", u, 5, JoinMiter, 1, 0, -1, []Coord{{-4, 50}, {50, 33}}, []Coord{{50, 60}}},
+		{"U inflate round", u, 5, JoinRound, 1, 0, -1, []Coord{{-4, 50}, {50, 33}}, []Coord{{50, 60}}},
+		{"U inflate square", u, 5, JoinSquare, 1, 0, -1, []Coord{{-4, 50}, {50, 33}}, []Coord{{50, 60}}},
+		{"U deflate", u, -14, JoinMiter, 1, 0, -1, []Coord{{15, 80}, {50, 15}}, []Coord{{50, 20}, {5, 50}}},
+		{"C inflate closes gap", c, 6, JoinMiter, 2, 0, -1, []Coord{{10, 50}}, []Coord{{50, 37}}},
+		{"invalid ring", []Coord{{0, 0}, {10, 0}, {20, 0}}, 10, JoinMiter, 0, 0, 0, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rings := Offset(tt.ring, tt.delta, tt.join)
+			if len(rings) != tt.rings {
+				t.Fatalf("Offset() = %v, want %d rings", rings, tt.rings)
+			}
+			if tt.epsilon >= 0 && math.Abs(ringsArea(rings)-tt.area) > tt.epsilon {
+				t.Fatalf("area = %v, want %v", ringsArea(rings), tt.area)
+			}
+			if len(rings) == 0 {
+				return
+			}
+			p := NewSimplePolygon(0, rings[0], rings[1:]...)
+			if err := p.Validate(); err != nil || signedArea2(p.Outer) <= 0 {
+				t.Fatalf("Offset() = %v is not a valid counter-clockwise polygon: %v", rings, err)
+			}
+			for _, c := range tt.in {
+				if !p.IsCoordInside(c) {
+					t.Fatalf("Offset() = %v does not cover %v", rings, c)
+				}
+			}
+			for _, c := range tt.out {
+				if p.IsCoordInside(c) {
+					t.Fatalf("Offset() = %v covers %v", rings, c)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplePolygonOffset(t *testing.T) {
+	h := NewSimplePolygon(0, []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}, []Coord{{40, 40}, {60, 40}, {60, 60}, {40, 60}})
+	c := NewSimplePolygon(0, []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}, {0, 55}, {80, 55}, {80, 20}, {20, 20}, {20, 45}, {0, 45}})
+	tests := []struct {
+		name  string
+		s     *SimplePolygon
+		delta int32
+		count int
+		holes int
+		area  float64
+	}{
+		{"inflate shrinks hole", h, 5, 1, 1, 110*110 - 10*10},
+		{"inflate fills hole", h, 11, 1, 0, 122 * 122},
+		{"deflate grows hole", h, -5, 1, 1, 90*90 - 30*30},
+		{"inflate closes gap", c, 6, 1, 1, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := tt.s.Offset(tt.delta, JoinMiter)
+			if len(ps) != tt.count || len(ps[0].Holes) != tt.holes || ps[0].Validate() != nil {
+				t.Fatalf("Offset() = %v, want %d polygons with %d holes", ps, tt.count, tt.holes)
+			}
+			if tt.area >= 0 && ps[0].Area() != tt.area {
+				t.Fatalf("Area() = %v, want %v", ps[0].Area(), tt.area)
+			}
+		})
+	}
+}
+
+// TestOffsetMatchesDistance 以随机星形多边形校验圆角偏移结果：
+// 与原多边形距离明显小于偏移距离的点被覆盖，明显大于偏移距离的点不被覆盖。
+func TestOffsetMatchesDistance(t *testing.T) {
+	rng := rand.New(rand.NewPCG(23, 0))
+	for range 100 {
+		n := 3 + rng.IntN(12)
+		ring := make([]Coord, n)
+		for i := range ring {
+			a := (float64(i) + rng.Float64()*0.8) * 2 * math.Pi / float64(n)
+			r := 100 + rng.Float64()*300
+			ring[i] = Coord{int32(math.Round(r * math.Cos(a))), int32(math.Round(r * math.Sin(a)))}
+		}
+		s := NewSimplePolygon(0, ring)
+		if s.Validate() != nil {
+			continue
+		}
+		delta := rng.Int32N(121) - 60
+		rings := Offset(ring, delta, JoinRound)
+		var ps []*SimplePolygon
+		for i := 0; i < len(rings); i++ {
+			p := NewSimplePolygon(0, rings[i])
+			for i+1 < len(rings) && signedArea2(rings[i+1]) < 0 {
+				i++
+				p.Holes = append(p.Holes, rings[i])
+			}
+			if err := p.Validate(); err != nil {
+				t.Fatalf("Offset(%v, %d) = %v is invalid: %v", ring, delta, rings, err)
+			}
+			ps = append(ps, p)
+		}
+		for range 200 {
+			x, z := rng.Int32N(1000)-500, rng.Int32N(1000)-500
+			d := math.Inf(1)
+			for i, c := range ring {
+				d = min(d, floatDistanceToSegment(NewSegment(c, ring[(i+1)%n]), float64(x), float64(z)))
+			}
+			// 以有向距离表示：多边形内为负
+			if s.IsCoordInside(Coord{x, z}) {
+				d = -d
+			}
+			// 圆弧采样与取整使边界偏移数个单位
+			if math.Abs(d-float64(delta)) <= 3 {
+				continue
+			}
+			if want := d < float64(delta); polygonsCover(ps, Coord{x, z}) != want {
+				t.Fatalf("Offset(%v, %d) covers %v = %v, signed distance %.2f", ring, delta, Coord{x, z}, !want, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package geo
+
+import "container/heap"
+
+// Heuristic 表示 A* 寻路的启发函数，估算从 from 到 to 的剩余代价。
+// 启发函数不应高估真实代价，否则 A* 无法保证找到最短走廊。
+type Heuristic func(from, to Coord) float64
+
+// EuclideanHeuristic 以两点之间的欧几里得距离作为启发值，是 PathFinder 的默认启发函数。
+func EuclideanHeuristic(from, to Coord) float64 {
+	return CalDstCoordToCoord(from, to)
+}
+
+// ZeroHeuristic 恒返回 0，使 A* 退化为 Dijkstra 搜索。
+func ZeroHeuristic(_, _ Coord) float64 {
+	return 0
+}
+
+// portalLink 描述某条边在某个多边形中的位置：多边形下标及其局部边下标。
+type portalLink struct {
+	polygon int
+	edge    int
+}
+
+// PathFinder 在由多边形（Triangle 或 Convex）构成的邻接图上执行 A* 搜索，
+// 求出起点到终点所经过的多边形走廊及相邻多边形之间的入口边（Portal）。
+//
+// 两个多边形共享同一个边序号即视为相邻；若提供了边表，则还要求该边的 IsAdjacency 为 true。
+// 多边形的第 i 条边须连接 GetVertices()[i] 与 GetVertices()[(i+1)%n]，
+// 与 GetEdgeIDs、GetEdgeMidCoords 的顺序一致。
+// 搜索节点为入口边，节点位置取边表中非零的 Edge.WtCoord，否则取边中点。
+type PathFinder struct {
+	Heuristic Heuristic // 启发函数，为 nil 时使用 EuclideanHeuristic
+
+	polygons []Polygon
+	edges    []*Edge
+	links    map[int32][]portalLink // 边序号 → 拥有该边的多边形
+	ccw      []bool                 // 各多边形顶点是否为逆时针排列
+	locator  *Locator               // 起点与终点所在多边形的定位索引
+	index    map[Polygon]int        // 多边形 → 下标
+	keys     map[int64]int32        // 边键（GenEdgeKey）→ 边序号，用于将入口边映射回边表
+}
+
+// NewPathFinder 以多边形集合和可选的边表（以边序号为下标，可为 nil）创建寻路器。
+// 构建阶段一次性建立边序号到多边形的索引，后续查询无需重复扫描。
+func NewPathFinder(polygons []Polygon, edges []*Edge) *PathFinder {
+	pf := &PathFinder{
+		Heuristic: EuclideanHeuristic,
+		polygons:  polygons,
+		edges:     edges,
+		links:     make(map[int32][]portalLink, len(polygons)*2),
+		ccw:       make([]bool, len(polygons)),
+		locator:   NewLocator(polygons),
+		index:     make(map[Polygon]int, len(polygons)),
+		keys:      make(map[int64]int32, len(polygons)*2),
+	}
+	for i, p := range polygons {
+		pf.index[p] = i
+		vertices := p.GetVertices()
+		coords := make([]Coord, len(vertices))
+		for j, v := range vertices {
+			coords[j] = v.Coord
+		}
+		pf.ccw[i] = signedArea2(coords) >= 0
+		for j, id := range p.GetEdgeIDs() {
+			pf.links[id] = append(pf.links[id], portalLink{polygon: i, edge: j})
+			pf.keys[GenEdgeKey(vertices[j].Index, vertices[(j+1)%len(vertices)].Index)] = id
+		}
+	}
+	return pf
+}
+
+// Locate 返回包含给定点的多边形下标，点不在任何多边形内时返回 false。
+func (pf *PathFinder) Locate(p Coord) (int, bool) {
+	polygon, ok := pf.locator.Locate(p)
+	if !ok {
+		return -1, false
+	}
+	return pf.index[polygon], true
+}
+
+// FindPath 搜索从 start 到 end 的多边形走廊。
+// 返回依次经过的多边形列表及相邻多边形之间的入口边，入口边数量比多边形少 1。
+// 每条入口边的 Vertices[0] 为沿行进方向的左端点，Vertices[1] 为右端点，可直接用于漏斗算法。
+// 起点或终点不在任何多边形内、或两者不连通时返回 false。
+func (pf *PathFinder) FindPath(start, end Coord) ([]Polygon, []Edge, bool) {
+	return pf.FindPathWithRadius(start, end, 0)
+}
+
+// FindPathWithRadius 为半径为 radius 的单位搜索多边形走廊。
+// 宽度小于 2*radius 的入口边无法容纳该单位，搜索时直接跳过，
+// 返回的走廊可交给 StringPullWithRadius 生成带转角圆弧的平滑路径。
+func (pf *PathFinder) FindPathWithRadius(start, end Coord, radius int32) ([]Polygon, []Edge, bool) {
+	from, ok := pf.Locate(start)
+	if !ok {
+		return nil, nil, false
+	}
+	to, ok := pf.Locate(end)
+	if !ok {
+		return nil, nil, false
+	}
+	if from == to {
+		return []Polygon{pf.polygons[from]}, nil, true
+	}
+
+	h := pf.Heuristic
+	if h == nil {
+		h = EuclideanHeuristic
+	}
+
+	// 搜索节点：经由某条入口边进入某个多边形；goal 为到达终点的虚拟节点
+	goal := pathNode{edge: -1, polygon: to}
+	cost := map[pathNode]float64{}
+	parent := map[pathNode]pathNode{}
+	closed := map[pathNode]bool{}
+	open := &pathQueue{}
+
+	push := func(n, prev pathNode, g float64, pos Coord) {
+		if old, ok := cost[n]; ok && old <= g {
+			return
+		}
+		cost[n] = g
+		parent[n] = prev
+		heap.Push(open, pathItem{node: n, pos: pos, f: g + h(pos, end)})
+	}
+	startNode := pathNode{edge: -1, polygon: from}
+	pf.expand(from, -1, radius, func(id int32, next int, pos Coord) {
+		push(pathNode{edge: id, polygon: next}, startNode, CalDstCoordToCoord(start, pos), pos)
+	})
+
+	for open.Len() > 0 {
+		item := heap.Pop(open).(pathItem)
+		cur := item.node
+		if closed[cur] {
+			continue
+		}
+		closed[cur] = true
+		if cur == goal {
+			return pf.buildCorridor(cur, startNode, parent)
+		}
+		g := cost[cur]
+		if cur.polygon == to {
+			push(goal, cur, g+CalDstCoordToCoord(item.pos, end), end)
+			continue
+		}
+		pf.expand(cur.polygon, cur.edge, radius, func(id int32, next int, pos Coord) {
+			push(pathNode{edge: id, polygon: next}, cur, g+CalDstCoordToCoord(item.pos, pos), pos)
+		})
+	}
+	return nil, nil, false
+}
+
+// expand 遍历多边形的所有可通行入口边（跳过进入时经过的边及宽度不足 2*radius 的边），
+// 回调相邻多边形及入口边位置。
+func (pf *PathFinder) expand(polygon int, from int32, radius int32, fn func(id int32, next int, pos Coord)) {
+	p := pf.polygons[polygon]
+	mids := p.GetEdgeMidCoords()
+	vertices := p.GetVertices()
+	// 比较宽度平方与 (2r)² 以避免开方
+	minWidthSq := 4 * float64(radius) * float64(radius)
+	for i, id := range p.GetEdgeIDs() {
+		if id == from || !pf.isPassable(id) {
+			continue
+		}
+		a, b := vertices[i].Coord, vertices[(i+1)%len(vertices)].Coord
+		if CalDstCoordToCoordWithoutSqrt(a, b) < minWidthSq {
+			continue
+		}
+		for _, l := range pf.links[id] {
+			if l.polygon == polygon {
+				continue
+			}
+			pos := mids[i]
+			if e := pf.edge(id); e != nil && e.WtCoord != (Coord{}) {
+				pos = e.WtCoord
+			}
+			fn(id, l.polygon, pos)
+		}
+	}
+}
+
+// isPassable 判断边是否可通行：须恰好被两个多边形共享，且边表中未标记为障碍边。
+func (pf *PathFinder) isPassable(id int32) bool {
+	if len(pf.links[id]) != 2 {
+		return false
+	}
+	if e := pf.edge(id); e != nil {
+		return e.IsAdjacency
+	}
+	return true
+}
+
+// edge 返回边表中的边，边表未提供或序号越界时返回 nil。
+func (pf *PathFinder) edge(id int32) *Edge {
+	if id < 0 || int(id) >= len(pf.edges) {
+		return nil
+	}
+	return pf.edges[id]
+}
+
+// buildCorridor 沿父节点链回溯，生成多边形走廊及按行进方向定向的入口边。
+func (pf *PathFinder) buildCorridor(goal, start pathNode, parent map[pathNode]pathNode) ([]Polygon, []Edge, bool) {
+	var nodes []pathNode
+	for n := parent[goal]; n != start; n = parent[n] {
+		nodes = append(nodes, n)
+	}
+	corridor := make([]Polygon, 0, len(nodes)+1)
+	portals := make([]Edge, 0, len(nodes))
+	corridor = append(corridor, pf.polygons[start.polygon])
+	prev := start.polygon
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		portals = append(portals, pf.portal(prev, n.edge))
+		corridor = append(corridor, pf.polygons[n.polygon])
+		prev = n.polygon
+	}
+	return corridor, portals, true
+}
+
+// portal 生成离开多边形时经过的入口边，并按行进方向定向为 [左端点, 右端点]。
+// 对逆时针多边形，从第 i 条边 v(i)→v(i+1) 向外穿出时，v(i+1) 位于左侧。
+func (pf *PathFinder) portal(polygon int, id int32) Edge {
+	var e Edge
+	if pe := pf.edge(id); pe != nil {
+		e = *pe
+	} else {
+		e.IsAdjacency = true
+	}
+	vertices := pf.polygons[polygon].GetVertices()
+	n := len(vertices)
+	for _, l := range pf.links[id] {
+		if l.polygon != polygon {
+			continue
+		}
+		left, right := vertices[(l.edge+1)%n], vertices[l.edge]
+		if !pf.ccw[polygon] {
+			left, right = right, left
+		}
+		e.Vertices = [2]Vertice{left, right}
+		if e.WtCoord == (Coord{}) {
+			e.WtCoord = e.CalMidCoord()
+		}
+		break
+	}
+	return e
+}
+
+// pathNode 表示 A* 搜索节点：经由入口边 edge 进入多边形 polygon。
+type pathNode struct {
+	edge    int32
+	polygon int
+}
+
+// pathItem 表示开放列表中的条目。
+type pathItem struct {
+	node pathNode
+	pos  Coord
+	f    float64
+}
+
+// pathQueue 是以 f 值为键的最小堆，实现 container/heap 接口。
+type pathQueue []pathItem
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].f < q[j].f }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)        { *q = append(*q, x.(pathItem)) }
+func (q *pathQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,134 @@
+package geo
+
+import "testing"
+
+// testMesh 返回 1000×1000 的正方形区域、中央挖去 600×600 方洞的导航网格，可通行区域为宽 200 的回字形走廊。
+func testMesh(t *testing.T) *NavMesh {
+	t.Helper()
+	outer := []Coord{{0, 0}, {1000, 0}, {1000, 1000}, {0, 1000}}
+	hole := []Coord{{200, 200}, {800, 200}, {800, 800}, {200, 800}}
+	m, err := NewNavMeshBuilder(outer, hole).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return m
+}
+
+// testPathFinder 以 testMesh 的三角形或合并后的凸多边形创建寻路器。
+func testPathFinder(t *testing.T, convex bool) (*PathFinder, *NavMesh) {
+	t.Helper()
+	m := testMesh(t)
+	var polygons []Polygon
+	if convex {
+		cs, err := m.MergeConvexes()
+		if err != nil {
+			t.Fatalf("MergeConvexes() error = %v", err)
+		}
+		for _, c := range cs {
+			polygons = append(polygons, c)
+		}
+	} else {
+		for _, tri := range m.Triangles {
+			polygons = append(polygons, tri)
+		}
+	}
+	return NewPathFinder(polygons, m.Edges), m
+}
+
+func TestPathFinderFindPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		convex     bool
+		start, end Coord
+		ok         bool
+	}{
+		{"triangles across corridor", false, Coord{100, 100}, Coord{900, 900}, true},
+		{"convexes across corridor", true, Coord{100, 100}, Coord{900, 900}, true},
+		{"same polygon", false, Coord{100, 100}, Coord{110, 100}, true},
+		{"start on outer border", false, Coord{0, 500}, Coord{1000, 500}, true},
+		{"end inside hole", false, Coord{100, 100}, Coord{500, 500}, false},
+		{"start outside mesh", true, Coord{-10, 100}, Coord{900, 900}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf, _ := testPathFinder(t, tt.convex)
+			corridor, portals, ok := pf.FindPath(tt.start, tt.end)
+			if ok != tt.ok {
+				t.Fatalf("FindPath() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if len(portals) != len(corridor)-1 {
+				t.Fatalf("len(portals) = %d, want %d", len(portals), len(corridor)-1)
+			}
+			if !corridor[0].IsCoordInside(tt.start) || !corridor[len(corridor)-1].IsCoordInside(tt.end) {
+				t.Fatal("corridor does not start at start polygon or end at end polygon")
+			}
+			// 入口边的 Vertices[0] 位于前进方向左侧：上一个多边形的重心在左右端点连线的右侧，下一个在左侧
+			for i, p := range portals {
+				a, b := polygonCenter(corridor[i]), polygonCenter(corridor[i+1])
+				left, right := p.Vertices[0].Coord, p.Vertices[1].Coord
+				if cross(left, a, right) <= 0 || cross(left, b, right) >= 0 {
+					t.Fatalf("portal %d is not oriented left/right along the corridor", i)
+				}
+			}
+		})
+	}
+}
+
+func TestPathFinderLocate(t *testing.T) {
+	pf, _ := testPathFinder(t, false)
+	tests := []struct {
+		p  Coord
+		ok bool
+	}{
+		{Coord{100, 100}, true},
+		{Coord{0, 0}, true},
+		{Coord{200, 500}, true},
+		{Coord{500, 500}, false},
+		{Coord{1001, 500}, false},
+	}
+	for _, tt := range tests {
+		if _, ok := pf.Locate(tt.p); ok != tt.ok {
+			t.Errorf("Locate(%v) ok = %v, want %v", tt.p, ok, tt.ok)
+		}
+	}
+}
+
+// polygonCenter 返回多边形顶点的平均坐标。
+func polygonCenter(p Polygon) Coord {
+	var x, z int64
+	vs := p.GetVertices()
+	for _, v := range vs {
+		x += int64(v.Coord.X)
+		z += int64(v.Coord.Z)
+	}
+	return Coord{X: int32(x / int64(len(vs))), Z: int32(z / int64(len(vs)))}
+}
+
+func TestPathFinderFindPathWithRadius(t *testing.T) {
+	tests := []struct {
+		name   string
+		radius int32
+		ok     bool
+	}{
+		{"zero radius", 0, true},
+		{"fits corridor", 50, true},
+		{"wider than corridor", 400, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf, _ := testPathFinder(t, false)
+			_, portals, ok := pf.FindPathWithRadius(Coord{100, 500}, Coord{900, 500}, tt.radius)
+			if ok != tt.ok {
+				t.Fatalf("FindPathWithRadius() ok = %v, want %v", ok, tt.ok)
+			}
+			for i, p := range portals {
+				if w := CalDstCoordToCoord(p.Vertices[0].Coord, p.Vertices[1].Coord); w < 2*float64(tt.radius) {
+					t.Fatalf("portal %d width %.1f is narrower than %d", i, w, 2*tt.radius)
+				}
+			}
+		})
+	}
+}
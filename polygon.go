@@ -22,24 +22,13 @@ type Polygon interface {
 
 // CrossProduct 计算以 p1→p2→p3 顺序连接的三个顶点处的有向叉积符号。
 // 返回 1 表示逆时针转弯（左转），-1 表示顺时针转弯（右转），0 表示共线。
-// 使用 int64 中间量防止 int32 坐标差值相乘时溢出，保证大坐标场景下的正确性。
+// (p2-p1)×(p3-p2) 与 (p2-p1)×(p3-p1) 同号，因此直接由 Orient2D 精确判定，全坐标范围内不会溢出。
 func CrossProduct(p1, p2, p3 Vertice) int32 {
-	ax := int64(p2.Coord.X - p1.Coord.X)
-	az := int64(p2.Coord.Z - p1.Coord.Z)
-	bx := int64(p3.Coord.X - p2.Coord.X)
-	bz := int64(p3.Coord.Z - p2.Coord.Z)
-	cp := ax*bz - az*bx
-	if cp > 0 {
-		return 1
-	} else if cp < 0 {
-		return -1
-	}
-
-	return 0
+	return int32(Orient2D(p1.Coord, p2.Coord, p3.Coord))
 }
 
 // IsConvex 验证给定顶点列表是否构成合法的凸多边形。
-// 凸多边形的判断依据：遍历所有相邻边对，若所有叉积符号一致
+// 凸多边形的判断依据：遍历所有相邻边对，若所有叉积符号（由 Orient2D 精确判定）一致
 // （全为正或全为负），则为凸多边形；若正负混合，则含凹角，不是凸多边形。
 // 注意：使用 positiveFlag != negativeFlag 而非直接统计数量，
 // 可以在发现两种符号都出现时立即识别为非凸（两个 bool 的异或），代码简洁高效。
@@ -51,9 +40,7 @@ func IsConvex(vertices []Vertice) bool {
 	negativeFlag := false
 	positiveFlag := false
 	for i := range numPoints {
-		curvec := NewVector(vertices[i].Coord, vertices[(i+1)%numPoints].Coord)
-		vec2next := NewVector(vertices[i].Coord, vertices[(i+2)%numPoints].Coord)
-		cp := curvec.Cross(&vec2next)
+		cp := Orient2D(vertices[i].Coord, vertices[(i+1)%numPoints].Coord, vertices[(i+2)%numPoints].Coord)
 		if cp > 0 {
 			positiveFlag = true
 		} else if cp < 0 {
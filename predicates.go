@@ -0,0 +1,208 @@
+package geo
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// 本文件提供精确、不溢出的几何谓词。
+// int32 坐标相减后的差值可达 2^32，两两相乘已超出 int64 的范围，
+// 因此方向判定以 128 位整数精确计算，InCircle 先以浮点误差界快速判定，无法确定时再以 math/big 精确求值。
+
+// inCircleErrBound 为 InCircle 浮点快速判定的相对误差界（Shewchuk 的 iccerrboundA），
+// 行列式的绝对值超过该误差界与各项绝对值之和的乘积时，浮点结果的符号必然正确。
+const inCircleErrBound = (10 + 96*0x1p-53) * 0x1p-53
+
+// Orient2D 精确判断点 c 相对于有向直线 a→b 的方位：返回 1 表示 c 在左侧（a、b、c 逆时针），
+// -1 表示在右侧（顺时针），0 表示三点共线。结果等于 (b-a)×(c-a) 的符号，在全部 int32 坐标范围内不会溢出。
+func Orient2D(a, b, c Coord) int {
+	return mulSub(
+		int64(b.X)-int64(a.X), int64(c.Z)-int64(a.Z),
+		int64(b.Z)-int64(a.Z), int64(c.X)-int64(a.X),
+	)
+}
+
+// InCircle 精确判断点 d 相对于逆时针三角形 abc 外接圆的位置：
+// 返回 1 表示在圆内，-1 表示在圆外，0 表示四点共圆；abc 为顺时针时结果取反。
+// 行列式各项可达坐标差的四次方，先以 float64 计算并用误差界判定符号，
+// 仅在接近共圆（如规则网格）时回退到 math/big 精确求值，保证 Delaunay 翻转不会因舍入误差死循环。
+func InCircle(a, b, c, d Coord) int {
+	adx, adz := float64(a.X)-float64(d.X), float64(a.Z)-float64(d.Z)
+	bdx, bdz := float64(b.X)-float64(d.X), float64(b.Z)-float64(d.Z)
+	cdx, cdz := float64(c.X)-float64(d.X), float64(c.Z)-float64(d.Z)
+	alift := adx*adx + adz*adz
+	blift := bdx*bdx + bdz*bdz
+	clift := cdx*cdx + cdz*cdz
+
+	bc1, bc2 := bdx*cdz, cdx*bdz
+	ca1, ca2 := cdx*adz, adx*cdz
+	ab1, ab2 := adx*bdz, bdx*adz
+	det := alift*(bc1-bc2) + blift*(ca1-ca2) + clift*(ab1-ab2)
+	permanent := (math.Abs(bc1)+math.Abs(bc2))*alift +
+		(math.Abs(ca1)+math.Abs(ca2))*blift +
+		(math.Abs(ab1)+math.Abs(ab2))*clift
+	if bound := inCircleErrBound * permanent; det > bound {
+		return 1
+	} else if -det > bound {
+		return -1
+	}
+	return inCircleExact(a, b, c, d)
+}
+
+// inCircleExact 以 math/big 精确计算 InCircle 的行列式符号。
+func inCircleExact(a, b, c, d Coord) int {
+	diff := func(p Coord) (*big.Int, *big.Int, *big.Int) {
+		dx := big.NewInt(int64(p.X) - int64(d.X))
+		dz := big.NewInt(int64(p.Z) - int64(d.Z))
+		lift := new(big.Int).Mul(dx, dx)
+		lift.Add(lift, new(big.Int).Mul(dz, dz))
+		return dx, dz, lift
+	}
+	adx, adz, alift := diff(a)
+	bdx, bdz, blift := diff(b)
+	cdx, cdz, clift := diff(c)
+
+	cross2 := func(x1, z1, x2, z2 *big.Int) *big.Int {
+		r := new(big.Int).Mul(x1, z2)
+		return r.Sub(r, new(big.Int).Mul(x2, z1))
+	}
+	det := new(big.Int).Mul(alift, cross2(bdx, bdz, cdx, cdz))
+	det.Add(det, new(big.Int).Mul(blift, cross2(cdx, cdz, adx, adz)))
+	det.Add(det, new(big.Int).Mul(clift, cross2(adx, adz, bdx, bdz)))
+	return det.Sign()
+}
+
+// RatCoord 表示分量为有理数的精确坐标，用于表示无法用整数坐标精确描述的交点。
+type RatCoord struct {
+	X *big.Rat
+	Z *big.Rat
+}
+
+// Round 将精确坐标四舍五入（恰为 0.5 时远离 0）为整数坐标。
+func (r RatCoord) Round() Coord {
+	return Coord{X: roundRat(r.X), Z: roundRat(r.Z)}
+}
+
+// roundRat 将有理数四舍五入（恰为 0.5 时远离 0）为 int32。
+func roundRat(r *big.Rat) int32 {
+	num, den := r.Num(), r.Denom()
+	twice := new(big.Int).Lsh(num, 1)
+	if num.Sign() < 0 {
+		twice.Sub(twice, den)
+	} else {
+		twice.Add(twice, den)
+	}
+	return int32(twice.Quo(twice, new(big.Int).Lsh(den, 1)).Int64())
+}
+
+// SegmentIntersection 精确计算线段 P0P1 与 Q0Q1 的唯一交点（含端点接触），以有理数坐标返回。
+// 相交判定由 isSegmentCross 精确完成（端点仅落在另一线段延长线上时不算相交）；两线段共线时仅在重叠部分恰为一个点时返回该点，
+// 不相交或共线重叠（交点不唯一）时返回 false。需要整数坐标时调用 RatCoord.Round。
+func SegmentIntersection(p0, p1, q0, q1 Coord) (RatCoord, bool) {
+	if !isSegmentCross(p0, p1, q0, q1) {
+		return RatCoord{}, false
+	}
+	num, den := lineCrossParams(p0, p1, q0, q1)
+	if den.Sign() == 0 {
+		// 共线：收集落在另一线段上的端点，去重后恰为一个点才是唯一交点
+		var touch []Coord
+		for _, c := range [4][3]Coord{{q0, q1, p0}, {q0, q1, p1}, {p0, p1, q0}, {p0, p1, q1}} {
+			if isCoordInRect(c[0], c[1], c[2]) && (len(touch) == 0 || touch[0] != c[2]) {
+				touch = append(touch, c[2])
+			}
+		}
+		if len(touch) != 1 {
+			return RatCoord{}, false
+		}
+		return RatCoord{X: new(big.Rat).SetInt64(int64(touch[0].X)), Z: new(big.Rat).SetInt64(int64(touch[0].Z))}, true
+	}
+	// 交点 = P0 + t·(P1 - P0)，t = num / den
+	axis := func(from, to int32) *big.Rat {
+		v := new(big.Int).Mul(big.NewInt(int64(to)-int64(from)), num)
+		v.Add(v, new(big.Int).Mul(big.NewInt(int64(from)), den))
+		return new(big.Rat).SetFrac(v, den)
+	}
+	return RatCoord{X: axis(p0.X, p1.X), Z: axis(p0.Z, p1.Z)}, true
+}
+
+// lineCrossParams 返回线段 ab 所在直线与直线 cd 交点的参数 t = num / den（交点为 a + t·(b - a)），
+// 其中 num = (c - a)×(d - c)，den = (b - a)×(d - c)；den 为 0 表示两直线平行或重合。
+func lineCrossParams(a, b, c, d Coord) (num, den *big.Int) {
+	crossBig := func(x1, z1, x2, z2 int64) *big.Int {
+		r := new(big.Int).Mul(big.NewInt(x1), big.NewInt(z2))
+		return r.Sub(r, new(big.Int).Mul(big.NewInt(x2), big.NewInt(z1)))
+	}
+	dcx, dcz := int64(d.X)-int64(c.X), int64(d.Z)-int64(c.Z)
+	num = crossBig(int64(c.X)-int64(a.X), int64(c.Z)-int64(a.Z), dcx, dcz)
+	den = crossBig(int64(b.X)-int64(a.X), int64(b.Z)-int64(a.Z), dcx, dcz)
+	return num, den
+}
+
+// isCoordInRect 判断点 p 是否在以 a、b 为对角的轴对齐矩形内（含边界），
+// 对与 ab 共线的点即为是否落在线段 ab 上。
+func isCoordInRect(a, b, p Coord) bool {
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+		min(a.Z, b.Z) <= p.Z && p.Z <= max(a.Z, b.Z)
+}
+
+// int128 表示 128 位有符号整数（补码），用于精确计算两个 int64 乘积的和差。
+type int128 struct {
+	hi int64
+	lo uint64
+}
+
+// mul128 返回两个 int64 的 128 位乘积。
+func mul128(a, b int64) int128 {
+	neg := (a < 0) != (b < 0)
+	ua, ub := uint64(a), uint64(b)
+	if a < 0 {
+		ua = -ua
+	}
+	if b < 0 {
+		ub = -ub
+	}
+	hi, lo := bits.Mul64(ua, ub)
+	if neg {
+		lo, hi = -lo, ^hi
+		if lo == 0 {
+			hi++
+		}
+	}
+	return int128{hi: int64(hi), lo: lo}
+}
+
+// add 返回 x + y。
+func (x int128) add(y int128) int128 {
+	lo, carry := bits.Add64(x.lo, y.lo, 0)
+	return int128{hi: x.hi + y.hi + int64(carry), lo: lo}
+}
+
+// sub 返回 x - y。
+func (x int128) sub(y int128) int128 {
+	lo, borrow := bits.Sub64(x.lo, y.lo, 0)
+	return int128{hi: x.hi - y.hi - int64(borrow), lo: lo}
+}
+
+// sign 返回 x 的符号。
+func (x int128) sign() int {
+	switch {
+	case x.hi < 0:
+		return -1
+	case x.hi > 0 || x.lo > 0:
+		return 1
+	}
+	return 0
+}
+
+// big 将 x 转换为 big.Int，值为 hi·2^64 + lo。
+func (x int128) big() *big.Int {
+	v := big.NewInt(x.hi)
+	v.Lsh(v, 64)
+	return v.Add(v, new(big.Int).SetUint64(x.lo))
+}
+
+// mulSub 精确返回 a×b − c×d 的符号。
+func mulSub(a, b, c, d int64) int {
+	return mul128(a, b).sub(mul128(c, d)).sign()
+}
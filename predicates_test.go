@@ -0,0 +1,206 @@
+package geo
+
+import (
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+const (
+	minCoord = math.MinInt32 // 坐标下界
+	maxCoord = math.MaxInt32 // 坐标上界
+)
+
+func TestOrient2D(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c Coord
+		want    int
+	}{
+		{"left", Coord{0, 0}, Coord{10, 0}, Coord{5, 3}, 1},
+		{"right", Coord{0, 0}, Coord{10, 0}, Coord{5, -3}, -1},
+		{"collinear beyond end", Coord{0, 0}, Coord{10, 0}, Coord{-100, 0}, 0},
+		{"degenerate line", Coord{3, 3}, Coord{3, 3}, Coord{5, 7}, 0},
+		{"extreme left", Coord{minCoord, minCoord}, Coord{maxCoord, maxCoord}, Coord{minCoord, maxCoord}, 1},
+		{"extreme right", Coord{minCoord, minCoord}, Coord{maxCoord, maxCoord}, Coord{maxCoord, minCoord}, -1},
+		{"extreme collinear", Coord{minCoord, minCoord}, Coord{maxCoord, maxCoord}, Coord{0, 0}, 0},
+		{"extreme nearly collinear", Coord{minCoord, minCoord}, Coord{maxCoord, maxCoord - 1}, Coord{0, 0}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Orient2D(tt.a, tt.b, tt.c); got != tt.want {
+				t.Fatalf("Orient2D() = %d, want %d", got, tt.want)
+			}
+			// 交换前两点结果取反，轮换三点结果不变
+			if got := Orient2D(tt.b, tt.a, tt.c); got != -tt.want {
+				t.Fatalf("Orient2D(b, a, c) = %d, want %d", got, -tt.want)
+			}
+			if got := Orient2D(tt.b, tt.c, tt.a); got != tt.want {
+				t.Fatalf("Orient2D(b, c, a) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	o := int32(1 << 29)
+	tests := []struct {
+		name       string
+		a, b, c, d Coord
+		want       int
+	}{
+		{"inside", Coord{0, 0}, Coord{10, 0}, Coord{0, 10}, Coord{4, 4}, 1},
+		{"outside", Coord{0, 0}, Coord{10, 0}, Coord{0, 10}, Coord{11, 11}, -1},
+		{"cocircular", Coord{0, 0}, Coord{10, 0}, Coord{0, 10}, Coord{10, 10}, 0},
+		{"clockwise flips", Coord{0, 0}, Coord{0, 10}, Coord{10, 0}, Coord{4, 4}, -1},
+		{"cocircular unit grid at large coordinates", Coord{o, o}, Coord{o + 1, o}, Coord{o + 1, o + 1}, Coord{o, o + 1}, 0},
+		{"just inside at large coordinates", Coord{o, o}, Coord{o + 2, o}, Coord{o + 2, o + 2}, Coord{o + 1, o + 2}, 1},
+		{"extreme triangle", Coord{minCoord, minCoord}, Coord{maxCoord, minCoord}, Coord{maxCoord, maxCoord}, Coord{minCoord, maxCoord}, 0},
+		{"extreme outside", Coord{minCoord, minCoord}, Coord{maxCoord, minCoord}, Coord{0, 0}, Coord{0, maxCoord}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InCircle(tt.a, tt.b, tt.c, tt.d); got != tt.want {
+				t.Fatalf("InCircle() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPredicatesMatchBigInt 以 math/big 直接展开的行列式校验随机坐标下的 Orient2D 与 InCircle。
+func TestPredicatesMatchBigInt(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	bigDiff := func(p, q int32) *big.Int {
+		return big.NewInt(int64(p) - int64(q))
+	}
+	orient := func(a, b, c Coord) int {
+		l := new(big.Int).Mul(bigDiff(b.X, a.X), bigDiff(c.Z, a.Z))
+		return l.Sub(l, new(big.Int).Mul(bigDiff(b.Z, a.Z), bigDiff(c.X, a.X))).Sign()
+	}
+	coords := []func() Coord{
+		func() Coord { return Coord{rng.Int32(), rng.Int32()} },
+		func() Coord { return Coord{int32(rng.Uint32()), int32(rng.Uint32())} },
+		func() Coord { return Coord{rng.Int32N(2000000) - 1000000, rng.Int32N(2000000) - 1000000} },
+		// 小范围内大量共线、共圆的情形
+		func() Coord { return Coord{rng.Int32N(5) + 1<<30, rng.Int32N(5) - 1<<30} },
+	}
+	for _, p := range coords {
+		for range 5000 {
+			a, b, c, d := p(), p(), p(), p()
+			if got, want := Orient2D(a, b, c), orient(a, b, c); got != want {
+				t.Fatalf("Orient2D(%v, %v, %v) = %d, want %d", a, b, c, got, want)
+			}
+			if got, want := InCircle(a, b, c, d), inCircleExact(a, b, c, d); got != want {
+				t.Fatalf("InCircle(%v, %v, %v, %v) = %d, want %d", a, b, c, d, got, want)
+			}
+		}
+	}
+}
+
+func TestLineSide(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Coord
+		p    Coord
+	}{
+		{"left", Coord{0, 0}, Coord{10, 0}, Coord{5, 3}},
+		{"right", Coord{0, 0}, Coord{10, 0}, Coord{5, -3}},
+		{"on line beyond end", Coord{0, 0}, Coord{10, 0}, Coord{-100, 0}},
+		{"extreme near vertical", Coord{minCoord + 1, 0}, Coord{maxCoord, 1}, Coord{maxCoord, 1}},
+		{"extreme vertical", Coord{minCoord, minCoord}, Coord{minCoord, maxCoord}, Coord{maxCoord, 0}},
+		{"extreme anti-diagonal", Coord{minCoord, maxCoord}, Coord{maxCoord, minCoord}, Coord{minCoord, minCoord}},
+		{"extreme horizontal", Coord{maxCoord, minCoord}, Coord{minCoord, minCoord}, Coord{0, maxCoord}},
+		{"extreme diagonal", Coord{minCoord, minCoord}, Coord{maxCoord, maxCoord}, Coord{maxCoord, minCoord}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLine(tt.a, tt.b)
+			if l.Side(tt.a) != 0 || l.Side(tt.b) != 0 {
+				t.Fatalf("%v does not pass through %v and %v", l, tt.a, tt.b)
+			}
+			want := -Orient2D(tt.a, tt.b, tt.p)
+			if got := l.Side(tt.p); got != want {
+				t.Fatalf("Side(%v) = %d, want %d", tt.p, got, want)
+			}
+			if got := l.IsCoordOnLine(tt.p); got != (want == 0) {
+				t.Fatalf("IsCoordOnLine(%v) = %v", tt.p, got)
+			}
+			if got := CrossProduct(Vertice{Coord: tt.a}, Vertice{Coord: tt.b}, Vertice{Coord: tt.p}); got != int32(-want) {
+				t.Fatalf("CrossProduct() = %d, want %d", got, -want)
+			}
+		})
+	}
+	if !IsConvex([]Vertice{{Coord: Coord{minCoord, minCoord}}, {Coord: Coord{maxCoord, minCoord}}, {Coord: Coord{maxCoord, maxCoord}}, {Coord: Coord{minCoord, maxCoord}}}) {
+		t.Fatalf("IsConvex() = false for the full coordinate range square")
+	}
+}
+
+func TestSegmentIntersection(t *testing.T) {
+	tests := []struct {
+		name           string
+		p0, p1, q0, q1 Coord
+		ok             bool
+		cross          bool // isSegmentCross 的结果，共线重叠时为 true 但没有唯一交点
+		straddle       bool // IsLineSegmentCross 的结果，端点落在另一线段所在直线上即为 true
+		x, z           *big.Rat
+		round          Coord
+	}{
+		{"crossing", Coord{0, 0}, Coord{10, 10}, Coord{0, 10}, Coord{10, 1}, true, true, true, big.NewRat(100, 19), big.NewRat(100, 19), Coord{5, 5}},
+		{"touching end", Coord{0, 0}, Coord{10, 0}, Coord{10, 0}, Coord{20, 5}, true, true, true, big.NewRat(10, 1), big.NewRat(0, 1), Coord{10, 0}},
+		{"collinear touching", Coord{0, 0}, Coord{10, 0}, Coord{10, 0}, Coord{20, 0}, true, true, true, big.NewRat(10, 1), big.NewRat(0, 1), Coord{10, 0}},
+		{"collinear overlap", Coord{0, 0}, Coord{10, 0}, Coord{5, 0}, Coord{20, 0}, false, true, true, nil, nil, Coord{}},
+		{"collinear apart", Coord{0, 0}, Coord{10, 0}, Coord{11, 0}, Coord{20, 0}, false, false, true, nil, nil, Coord{}},
+		{"on extended line", Coord{0, 0}, Coord{10, 0}, Coord{20, 0}, Coord{20, 10}, false, false, true, nil, nil, Coord{}},
+		{"parallel", Coord{0, 0}, Coord{10, 0}, Coord{0, 1}, Coord{10, 1}, false, false, false, nil, nil, Coord{}},
+		{"extreme diagonals", Coord{minCoord, minCoord}, Coord{maxCoord, maxCoord}, Coord{minCoord, maxCoord}, Coord{maxCoord, minCoord}, true, true, true, big.NewRat(-1, 2), big.NewRat(-1, 2), Coord{-1, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := SegmentIntersection(tt.p0, tt.p1, tt.q0, tt.q1)
+			if ok != tt.ok {
+				t.Fatalf("SegmentIntersection() ok = %v, want %v", ok, tt.ok)
+			}
+			if got := isSegmentCross(tt.p0, tt.p1, tt.q0, tt.q1); got != tt.cross {
+				t.Fatalf("isSegmentCross() = %v, want %v", got, tt.cross)
+			}
+			if got := IsLineSegmentCross(tt.p0, tt.p1, tt.q0, tt.q1); got != tt.straddle {
+				t.Fatalf("IsLineSegmentCross() = %v, want %v", got, tt.straddle)
+			}
+			if !ok {
+				return
+			}
+			if r.X.Cmp(tt.x) != 0 || r.Z.Cmp(tt.z) != 0 || r.Round() != tt.round {
+				t.Fatalf("SegmentIntersection() = (%v, %v) rounded %v, want (%v, %v) rounded %v", r.X, r.Z, r.Round(), tt.x, tt.z, tt.round)
+			}
+		})
+	}
+}
+
+// TestGetCrossCoordMatchesExact 校验 GetCrossCoord 的 int64 快速路径与 math/big 路径都等于精确交点向 P0 截断的结果。
+func TestGetCrossCoordMatchesExact(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	for _, span := range []int32{2000, 1 << 19, 1 << 22, maxCoord} {
+		for range 3000 {
+			p := func() Coord { return Coord{rng.Int32N(span), rng.Int32N(span)} }
+			p0, p1, q0, q1 := p(), p(), p(), p()
+			c, ok := GetCrossCoord(p0, p1, q0, q1)
+			r, exact := SegmentIntersection(p0, p1, q0, q1)
+			num, den := lineCrossParams(p0, p1, q0, q1)
+			if ok != (exact && den.Sign() != 0) {
+				t.Fatalf("GetCrossCoord(%v, %v, %v, %v) ok = %v, SegmentIntersection ok = %v", p0, p1, q0, q1, ok, exact)
+			}
+			if !ok {
+				continue
+			}
+			// 偏移量 t·S1 向零截断
+			axis := func(from, to int32) int32 {
+				v := new(big.Int).Mul(big.NewInt(int64(to)-int64(from)), num)
+				return from + int32(v.Quo(v, den).Int64())
+			}
+			if want := (Coord{axis(p0.X, p1.X), axis(p0.Z, p1.Z)}); c != want {
+				t.Fatalf("GetCrossCoord(%v, %v, %v, %v) = %v, want %v (exact %v, %v)", p0, p1, q0, q1, c, want, r.X, r.Z)
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package geo
+
+// Bounder 表示可退化为轴对齐包围盒（AABB）的对象。
+// Triangle、Convex、Circle 等形状均已实现 ToRect，可直接作为空间索引的元素。
+type Bounder interface {
+	ToRect() (minX, minZ, maxX, maxZ int32)
+}
+
+// quadrants 按子节点下标顺序列出四个象限的位掩码。
+var quadrants = [4]LocationState{LeftTop, RightTop, LeftBottom, RightBottom}
+
+// QuadTree 表示以 Border 为区域划分单元的泛型四叉树空间索引。
+// 元素仅存储在叶子节点中；跨越多个象限的元素依据 RectLocation 返回的位掩码
+// 同时存入所有重叠的子节点，查询时自动去重。
+// 叶子节点元素数超过 capacity 且深度未达 maxDepth 时分裂为四个子节点，
+// 删除元素后若子节点元素总数不超过 capacity 则合并回父节点。
+type QuadTree[T interface {
+	comparable
+	Bounder
+}] struct {
+	root     *quadNode[T]
+	maxDepth int
+	capacity int
+	nodes    map[T][]*quadNode[T] // 元素 → 持有该元素的叶子节点
+}
+
+// quadNode 表示四叉树节点，children 下标与 quadrants 一一对应。
+type quadNode[T comparable] struct {
+	border   Border
+	depth    int
+	parent   *quadNode[T]
+	children *[4]*quadNode[T]
+	items    []T
+}
+
+// NewQuadTree 以根区域、最大深度和叶子容量创建四叉树。
+// maxDepth 限制递归深度，防止大量元素堆叠于同一点时无限分裂；capacity 至少为 1。
+func NewQuadTree[T interface {
+	comparable
+	Bounder
+}](border Border, maxDepth, capacity int) *QuadTree[T] {
+	return &QuadTree[T]{
+		root:     &quadNode[T]{border: border},
+		maxDepth: maxDepth,
+		capacity: max(capacity, 1),
+		nodes:    make(map[T][]*quadNode[T]),
+	}
+}
+
+// Len 返回四叉树中的元素数量。
+func (q *QuadTree[T]) Len() int {
+	return len(q.nodes)
+}
+
+// Insert 插入元素，元素包围盒与根区域不相交或元素已存在时返回 false。
+func (q *QuadTree[T]) Insert(item T) bool {
+	if _, ok := q.nodes[item]; ok {
+		return false
+	}
+	minX, minZ, maxX, maxZ := item.ToRect()
+	if q.root.border.RectLocation(minX, minZ, maxX, maxZ) == 0 {
+		return false
+	}
+	q.insert(q.root, item, minX, minZ, maxX, maxZ)
+	return true
+}
+
+// insert 将元素递归插入与其包围盒重叠的所有叶子节点，必要时分裂叶子。
+func (q *QuadTree[T]) insert(n *quadNode[T], item T, minX, minZ, maxX, maxZ int32) {
+	if n.children != nil {
+		location := n.border.RectLocation(minX, minZ, maxX, maxZ)
+		for i, quadrant := range quadrants {
+			if location&quadrant != 0 {
+				q.insert(n.children[i], item, minX, minZ, maxX, maxZ)
+			}
+		}
+		return
+	}
+	n.items = append(n.items, item)
+	q.nodes[item] = append(q.nodes[item], n)
+	if len(n.items) > q.capacity && n.depth < q.maxDepth && n.border.Width >= 2 && n.border.Height >= 2 {
+		q.split(n)
+	}
+}
+
+// split 将叶子节点按中心点划分为四个子节点，并把原有元素重新分配到子节点中。
+// 子区域的划分方式与 RectLocation 一致：X 小于中心为左，Z 大于等于中心为上。
+func (q *QuadTree[T]) split(n *quadNode[T]) {
+	b := n.border
+	halfW := b.Width / 2
+	halfH := b.Height / 2
+	n.children = &[4]*quadNode[T]{
+		{border: NewBorder(b.X, b.Z+halfH, halfW, b.Height-halfH)},
+		{border: NewBorder(b.X+halfW, b.Z+halfH, b.Width-halfW, b.Height-halfH)},
+		{border: NewBorder(b.X, b.Z, halfW, halfH)},
+		{border: NewBorder(b.X+halfW, b.Z, b.Width-halfW, halfH)},
+	}
+	for _, child := range n.children {
+		child.depth = n.depth + 1
+		child.parent = n
+	}
+	items := n.items
+	n.items = nil
+	for _, item := range items {
+		q.detach(item, n)
+		minX, minZ, maxX, maxZ := item.ToRect()
+		q.insert(n, item, minX, minZ, maxX, maxZ)
+	}
+}
+
+// Remove 删除元素，元素不存在时返回 false。
+func (q *QuadTree[T]) Remove(item T) bool {
+	nodes, ok := q.nodes[item]
+	if !ok {
+		return false
+	}
+	delete(q.nodes, item)
+	for _, n := range nodes {
+		for i, it := range n.items {
+			if it == item {
+				n.items[i] = n.items[len(n.items)-1]
+				n.items = n.items[:len(n.items)-1]
+				break
+			}
+		}
+	}
+	for _, n := range nodes {
+		q.tryMerge(n.parent)
+	}
+	return true
+}
+
+// Update 在元素包围盒变化后重新定位该元素，等价于先删除再插入。
+// 新包围盒与根区域不相交时元素被移出四叉树并返回 false。
+func (q *QuadTree[T]) Update(item T) bool {
+	q.Remove(item)
+	return q.Insert(item)
+}
+
+// tryMerge 当节点的子节点均为叶子且去重后的元素总数不超过容量时，将子节点合并回该节点。
+func (q *QuadTree[T]) tryMerge(n *quadNode[T]) {
+	if n == nil || n.children == nil {
+		return
+	}
+	seen := map[T]bool{}
+	var items []T
+	for _, child := range n.children {
+		if child.children != nil {
+			return
+		}
+		for _, item := range child.items {
+			if !seen[item] {
+				seen[item] = true
+				items = append(items, item)
+			}
+		}
+	}
+	if len(items) > q.capacity {
+		return
+	}
+	for _, child := range n.children {
+		for _, item := range child.items {
+			q.detach(item, child)
+		}
+	}
+	n.children = nil
+	n.items = items
+	for _, item := range items {
+		q.nodes[item] = append(q.nodes[item], n)
+	}
+	q.tryMerge(n.parent)
+}
+
+// detach 从元素的节点列表中移除指定节点。
+func (q *QuadTree[T]) detach(item T, n *quadNode[T]) {
+	nodes := q.nodes[item]
+	for i, node := range nodes {
+		if node == n {
+			nodes[i] = nodes[len(nodes)-1]
+			q.nodes[item] = nodes[:len(nodes)-1]
+			return
+		}
+	}
+}
+
+// QueryRect 返回包围盒与给定矩形重叠的所有元素（含边界接触），结果不重复。
+// 仅做包围盒粗筛，精确的形状相交判断由调用方完成。
+func (q *QuadTree[T]) QueryRect(rect Rectangle) []T {
+	minX, minZ := rect.X, rect.Z
+	maxX, maxZ := rect.X+rect.Width, rect.Z+rect.Height
+	var ret []T
+	seen := map[T]bool{}
+	var visit func(n *quadNode[T])
+	visit = func(n *quadNode[T]) {
+		location := n.border.RectLocation(minX, minZ, maxX, maxZ)
+		if location == 0 {
+			return
+		}
+		if n.children != nil {
+			for i, quadrant := range quadrants {
+				if location&quadrant != 0 {
+					visit(n.children[i])
+				}
+			}
+			return
+		}
+		for _, item := range n.items {
+			if seen[item] {
+				continue
+			}
+			x0, z0, x1, z1 := item.ToRect()
+			if x0 <= maxX && minX <= x1 && z0 <= maxZ && minZ <= z1 {
+				seen[item] = true
+				ret = append(ret, item)
+			}
+		}
+	}
+	visit(q.root)
+	return ret
+}
+
+// QueryCoord 返回包围盒包含给定点的所有元素（含边界）。
+// 点只属于唯一象限，借助 CoordLocation 沿单一路径下降到叶子，复杂度 O(depth + capacity)。
+func (q *QuadTree[T]) QueryCoord(p Coord) []T {
+	n := q.root
+	for n.children != nil {
+		location := n.border.CoordLocation(p)
+		if location == 0 {
+			return nil
+		}
+		for i, quadrant := range quadrants {
+			if location == quadrant {
+				n = n.children[i]
+				break
+			}
+		}
+	}
+	if !n.border.IsCoordInside(p) {
+		return nil
+	}
+	var ret []T
+	for _, item := range n.items {
+		minX, minZ, maxX, maxZ := item.ToRect()
+		if minX <= p.X && p.X <= maxX && minZ <= p.Z && p.Z <= maxZ {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
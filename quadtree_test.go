@@ -0,0 +1,124 @@
+package geo
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestQuadTreeQuery(t *testing.T) {
+	circles := []*Circle{
+		{Center: Coord{100, 100}, Radius: 50},    // 左下象限
+		{Center: Coord{900, 900}, Radius: 50},    // 右上象限
+		{Center: Coord{500, 500}, Radius: 100},   // 跨越四个象限
+		{Center: Coord{250, 750}, Radius: 10},    // 左上象限
+		{Center: Coord{5000, 5000}, Radius: 100}, // 根区域之外
+	}
+	q := NewQuadTree[*Circle](NewBorder(0, 0, 1000, 1000), 4, 1)
+	for i, c := range circles {
+		if ok := q.Insert(c); ok != (i < 4) {
+			t.Fatalf("Insert(circle %d) = %v, want %v", i, ok, i < 4)
+		}
+	}
+	if q.Insert(circles[0]) {
+		t.Fatal("Insert() of an existing item = true, want false")
+	}
+	tests := []struct {
+		name  string
+		rect  Rectangle
+		coord bool // 以 rect.Coord 作为坐标点查询
+		want  []int
+	}{
+		{name: "point in one circle", rect: NewRectangle(100, 100, 0, 0), coord: true, want: []int{0}},
+		{name: "point on bounding box corner", rect: NewRectangle(50, 50, 0, 0), coord: true, want: []int{0}},
+		{name: "point in crossing circle", rect: NewRectangle(450, 520, 0, 0), coord: true, want: []int{2}},
+		{name: "point in empty area", rect: NewRectangle(700, 100, 0, 0), coord: true},
+		{name: "rect covering all", rect: NewRectangle(0, 0, 1000, 1000), want: []int{0, 1, 2, 3}},
+		{name: "rect touching boundary", rect: NewRectangle(150, 0, 100, 100), want: []int{0}},
+		{name: "rect across quadrants", rect: NewRectangle(200, 380, 200, 400), want: []int{2, 3}},
+		{name: "rect outside root", rect: NewRectangle(4000, 4000, 2000, 2000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*Circle
+			if tt.coord {
+				got = q.QueryCoord(tt.rect.Coord)
+			} else {
+				got = q.QueryRect(tt.rect)
+			}
+			var idx []int
+			for _, c := range got {
+				idx = append(idx, slices.Index(circles, c))
+			}
+			slices.Sort(idx)
+			if !slices.Equal(idx, tt.want) {
+				t.Fatalf("query = %v, want %v", idx, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuadTreeMatchesBruteForce(t *testing.T) {
+	tests := []struct {
+		name               string
+		maxDepth, capacity int
+		items, removes     int
+	}{
+		{"shallow", 2, 4, 500, 100},
+		{"deep", 8, 4, 2000, 1500},
+		{"capacity one", 10, 1, 300, 300},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(uint64(i), 2))
+			q := NewQuadTree[*Circle](NewBorder(0, 0, 10000, 10000), tt.maxDepth, tt.capacity)
+			var cs []*Circle
+			for range tt.items {
+				c := &Circle{Center: Coord{r.Int32N(10000), r.Int32N(10000)}, Radius: r.Int32N(300)}
+				cs = append(cs, c)
+				if !q.Insert(c) {
+					t.Fatal("Insert() = false")
+				}
+			}
+			check := func() {
+				t.Helper()
+				for range 200 {
+					rect := NewRectangle(r.Int32N(10000), r.Int32N(10000), r.Int32N(800), r.Int32N(800))
+					got := q.QueryRect(rect)
+					atCoord := q.QueryCoord(rect.Coord)
+					for _, c := range cs {
+						x0, z0, x1, z1 := c.ToRect()
+						in := x0 <= rect.X && rect.X <= x1 && z0 <= rect.Z && rect.Z <= z1
+						if in != slices.Contains(atCoord, c) {
+							t.Fatalf("QueryCoord(%v) mismatch for %v", rect.Coord, c)
+						}
+						overlap := x0 <= rect.X+rect.Width && rect.X <= x1 && z0 <= rect.Z+rect.Height && rect.Z <= z1
+						want := 0
+						if overlap {
+							want = 1
+						}
+						if n := len(slices.DeleteFunc(slices.Clone(got), func(x *Circle) bool { return x != c })); n != want {
+							t.Fatalf("QueryRect(%v) returned %v %d times, want %d", rect, c, n, want)
+						}
+					}
+				}
+			}
+			check()
+			for range tt.removes {
+				c := cs[len(cs)-1]
+				cs = cs[:len(cs)-1]
+				if !q.Remove(c) {
+					t.Fatal("Remove() = false")
+				}
+			}
+			for _, c := range cs {
+				c.Center.X = r.Int32N(10000)
+				q.Update(c)
+			}
+			check()
+			if q.Len() != len(cs) {
+				t.Fatalf("Len() = %d, want %d", q.Len(), len(cs))
+			}
+		})
+	}
+}
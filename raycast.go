@@ -0,0 +1,160 @@
+package geo
+
+import "math"
+
+// RaycastHit 描述射线检测的结果。
+type RaycastHit struct {
+	Coord    Coord     // 射线终止点：命中时为与阻挡边的交点，否则为射线终点
+	EdgeID   int32     // 阻挡边的边序号，未命中时为 -1
+	Edge     Edge      // 阻挡边（非邻接边），未命中时为零值
+	Fraction float64   // 终止点到起点的距离占 maxDist 的比例，未命中时为 1
+	Polygons []Polygon // 射线依次穿过的多边形，首个为起点所在多边形
+}
+
+// Raycast 从 start 沿 dir 方向发射长度为 maxDist 的射线，沿相邻多边形的公共边逐个穿越，
+// 直到遇到不可通行的边（网格边界或 IsAdjacency 为 false 的边）或到达射线终点。
+// 适用于"直走到撞墙"的位移计算与视线（Line of Sight）判断，多边形可以是 Triangle 或 Convex。
+//
+// 每个多边形内用 IsRectCross 与 IsLineSegmentCross 找出射线穿出的边（参数最大者），
+// 命中阻挡边时由 GetCrossCoord 求出交点。
+// 命中时返回 true；起点不在任何多边形内时视为在起点处命中，Polygons 为空、EdgeID 为 -1。
+// maxDist 超出 int32 坐标范围时射线在坐标范围边界处截止；因数值退化找不到穿出边时，
+// 视为在进入当前多边形处命中，EdgeID 为 -1。
+func (pf *PathFinder) Raycast(start Coord, dir Vector, maxDist float64) (RaycastHit, bool) {
+	hit := RaycastHit{Coord: start, EdgeID: -1}
+	cur, ok := pf.Locate(start)
+	if !ok {
+		return hit, true
+	}
+	hit.Polygons = []Polygon{pf.polygons[cur]}
+	length := dir.Length()
+	if length == 0 || maxDist <= 0 {
+		hit.Fraction = 1
+		return hit, false
+	}
+	// 射线长度限制在 int32 坐标范围内，避免终点回绕到反方向；Fraction 仍以原始 maxDist 计算。
+	// 终点决定了射线方向，固定取最近整数而不随 Rounding 截断，以减小方向误差
+	dist := min(maxDist, rayDistLimit(start.X, float64(dir.X)/length), rayDistLimit(start.Z, float64(dir.Z)/length))
+	end := Coord{
+		X: addInt32(start.X, RoundHalfEven.Round(float64(dir.X)/length*dist)),
+		Z: addInt32(start.Z, RoundHalfEven.Round(float64(dir.Z)/length*dist)),
+	}
+
+	from := int32(-1)
+	for range pf.polygons {
+		polygon := pf.polygons[cur]
+		if polygon.IsCoordInside(end) {
+			break
+		}
+		i, id, ok := pf.exitEdge(polygon, from, start, end)
+		if !ok {
+			// 终点不在多边形内却找不到穿出边（射线恰好擦过顶点等退化情形），
+			// 保守地视为在进入当前多边形处被阻挡，而不是报告未命中
+			hit.Coord = pf.entryCoord(polygon, from, start, end)
+			hit.Fraction = CalDstCoordToCoord(start, hit.Coord) / maxDist
+			return hit, true
+		}
+		if !pf.isPassable(id) {
+			vertices := polygon.GetVertices()
+			a, b := vertices[i], vertices[(i+1)%len(vertices)]
+			p, ok := GetCrossCoord(start, end, a.Coord, b.Coord)
+			if !ok {
+				p = start
+			}
+			hit.Coord = p
+			hit.EdgeID = id
+			if e := pf.edge(id); e != nil {
+				hit.Edge = *e
+			}
+			hit.Edge.Vertices = [2]Vertice{a, b}
+			hit.Fraction = CalDstCoordToCoord(start, p) / maxDist
+			return hit, true
+		}
+		next := -1
+		for _, l := range pf.links[id] {
+			if l.polygon != cur {
+				next = l.polygon
+				break
+			}
+		}
+		from, cur = id, next
+		hit.Polygons = append(hit.Polygons, pf.polygons[cur])
+	}
+	hit.Coord = end
+	hit.Fraction = 1
+	return hit, false
+}
+
+// rayDistLimit 返回从坐标分量 c 沿单位方向分量 d 前进、不超出 int32 范围的最大距离。
+func rayDistLimit(c int32, d float64) float64 {
+	switch {
+	case d > 0:
+		return (math.MaxInt32 - float64(c)) / d
+	case d < 0:
+		return (math.MinInt32 - float64(c)) / d
+	}
+	return math.Inf(1)
+}
+
+// entryCoord 返回线段 start→end 进入多边形时经过边 from 的交点，from 为 -1（起点所在多边形）或求交失败时返回 start。
+func (pf *PathFinder) entryCoord(polygon Polygon, from int32, start, end Coord) Coord {
+	vertices := polygon.GetVertices()
+	for i, id := range polygon.GetEdgeIDs() {
+		if id != from || from < 0 {
+			continue
+		}
+		if p, ok := GetCrossCoord(start, end, vertices[i].Coord, vertices[(i+1)%len(vertices)].Coord); ok {
+			return p
+		}
+	}
+	return start
+}
+
+// LineOfSight 判断两点之间的连线是否完全位于导航网格内、不被任何阻挡边遮挡。
+func (pf *PathFinder) LineOfSight(a, b Coord) bool {
+	dist := CalDstCoordToCoord(a, b)
+	if dist == 0 {
+		_, ok := pf.Locate(a)
+		return ok
+	}
+	_, blocked := pf.Raycast(a, NewVector(a, b), dist)
+	return !blocked
+}
+
+// exitEdge 找出线段 start→end 穿出多边形的边：在与线段相交的边中（跳过进入时经过的边 from），
+// 取交点在线段上参数最大者，返回其局部边下标与边序号。与线段平行的边不视为穿出边。
+func (pf *PathFinder) exitEdge(polygon Polygon, from int32, start, end Coord) (int, int32, bool) {
+	vertices := polygon.GetVertices()
+	ids := polygon.GetEdgeIDs()
+	ray := NewVector(start, end)
+	best, bestT := -1, -1.0
+	for i, id := range ids {
+		if id == from {
+			continue
+		}
+		a, b := vertices[i].Coord, vertices[(i+1)%len(vertices)].Coord
+		if !IsRectCross(start, end, a, b) || !IsLineSegmentCross(start, end, a, b) {
+			continue
+		}
+		edge := NewVector(a, b)
+		denominator := ray.Cross(&edge)
+		if denominator == 0 {
+			continue
+		}
+		// 交点在射线上的参数 t = (a - start) × edge / (ray × edge)，在边上的参数 u = (a - start) × ray / (ray × edge)。
+		// IsLineSegmentCross 在端点落在另一线段的延长线上时也返回 true，须再确认 u 落在边内
+		offset := NewVector(start, a)
+		t := float64(offset.Cross(&edge)) / float64(denominator)
+		u := float64(offset.Cross(&ray)) / float64(denominator)
+		if t < 0 || t > 1 || u < 0 || u > 1 {
+			continue
+		}
+		if t > bestT {
+			best, bestT = i, t
+		}
+	}
+	if best < 0 {
+		return 0, 0, false
+	}
+	return best, ids[best], true
+}
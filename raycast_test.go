@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPathFinderRaycast(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    Coord
+		dir      Vector
+		maxDist  float64
+		hit      bool
+		coord    Coord
+		fraction float64
+		polygons bool // 起点位于网格内，Polygons 非空
+	}{
+		{"hit outer border", Coord{100, 100}, Vector{1, 0}, 2000, true, Coord{1000, 100}, 0.45, true},
+		{"stop before border", Coord{100, 100}, Vector{1, 0}, 500, false, Coord{600, 100}, 1, true},
+		{"hit hole edge", Coord{100, 500}, Vector{1, 0}, 1000, true, Coord{200, 500}, 0.1, true},
+		{"hit border downwards", Coord{100, 100}, Vector{0, -5}, 1000, true, Coord{100, 0}, 0.1, true},
+		{"oblique hit", Coord{100, 100}, Vector{1, 2}, 1000, true, Coord{200, 300}, math.Sqrt(50000) / 1000, true},
+		{"zero direction", Coord{100, 100}, Vector{}, 1000, false, Coord{100, 100}, 1, true},
+		{"start inside hole", Coord{500, 500}, Vector{1, 0}, 1000, true, Coord{500, 500}, 0, false},
+		{"distance beyond int32 range", Coord{50, 10}, Vector{0, 1}, 1e10, true, Coord{50, 1000}, 990 / 1e10, true},
+	}
+	for _, convex := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pf, _ := testPathFinder(t, convex)
+				h, hit := pf.Raycast(tt.start, tt.dir, tt.maxDist)
+				if hit != tt.hit || h.Coord != tt.coord || math.Abs(h.Fraction-tt.fraction) > 1e-9 {
+					t.Fatalf("Raycast() = %v, %v, %v, want %v, %v, %v", h.Coord, h.Fraction, hit, tt.coord, tt.fraction, tt.hit)
+				}
+				if (len(h.Polygons) > 0) != tt.polygons {
+					t.Fatalf("len(Polygons) = %d", len(h.Polygons))
+				}
+				if hit && tt.polygons && (h.EdgeID < 0 || h.Edge.IsAdjacency) {
+					t.Fatalf("hit edge %d is not a blocking edge", h.EdgeID)
+				}
+			})
+		}
+	}
+}
+
+func TestPathFinderLineOfSight(t *testing.T) {
+	pf, _ := testPathFinder(t, false)
+	tests := []struct {
+		name string
+		a, b Coord
+		want bool
+	}{
+		{"along corridor", Coord{100, 100}, Coord{900, 100}, true},
+		{"across hole", Coord{100, 100}, Coord{900, 900}, false},
+		{"same point", Coord{100, 100}, Coord{100, 100}, true},
+		{"same point in hole", Coord{500, 500}, Coord{500, 500}, false},
+		{"leaving mesh", Coord{100, 100}, Coord{-100, 100}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pf.LineOfSight(tt.a, tt.b); got != tt.want {
+				t.Fatalf("LineOfSight(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPathFinderRaycastMatchesSampling 以细分采样的射线校验随机起点与方向下的命中位置。
+func TestPathFinderRaycastMatchesSampling(t *testing.T) {
+	walkable := func(x, z float64) bool {
+		return x >= 0 && x <= 1000 && z >= 0 && z <= 1000 && !(x > 200 && x < 800 && z > 200 && z < 800)
+	}
+	for _, convex := range []bool{false, true} {
+		pf, _ := testPathFinder(t, convex)
+		rng := rand.New(rand.NewPCG(17, 0))
+		for range 2000 {
+			var s Coord
+			for !walkable(float64(s.X), float64(s.Z)) || s == (Coord{}) {
+				s = Coord{rng.Int32N(1001), rng.Int32N(1001)}
+			}
+			dir := Vector{rng.Int32N(2001) - 1000, rng.Int32N(2001) - 1000}
+			if dir == (Vector{}) {
+				continue
+			}
+			maxDist := 50 + rng.Float64()*1500
+			h, hit := pf.Raycast(s, dir, maxDist)
+			// Raycast 沿起点到取整后终点的线段前进，采样同一条线段，掠射时方向误差不会被放大
+			l := dir.Length()
+			end := Coord{s.X + int32(math.Round(float64(dir.X)/l*maxDist)), s.Z + int32(math.Round(float64(dir.Z)/l*maxDist))}
+			sampled := 1.0
+			for i := 0; i <= 20000; i++ {
+				k := float64(i) / 20000
+				x, z := float64(s.X)+k*float64(end.X-s.X), float64(s.Z)+k*float64(end.Z-s.Z)
+				if !walkable(x, z) {
+					sampled = math.Hypot(x-float64(s.X), z-float64(s.Z)) / maxDist
+					break
+				}
+			}
+			// 射线恰好擦过洞的角点时 Raycast 视为被阻挡，采样则视为可通过
+			if hit && slices.Contains([]Coord{{200, 200}, {800, 200}, {800, 800}, {200, 800}}, h.Coord) {
+				continue
+			}
+			// 交点取整到整数坐标，允许数个单位的误差
+			if hit && math.Abs(h.Fraction-sampled)*maxDist > 3 || !hit && (1-sampled)*maxDist > 3 {
+				t.Fatalf("Raycast(%v, %v, %.1f) = %v, %v, sampled fraction %v", s, dir, maxDist, h.Fraction, hit, sampled)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package geo
+
+import "math"
+
+// Ring 表示圆环区域，由圆心与内外半径定义，常用于环形技能的命中判定。
+// 圆环覆盖到圆心距离位于 [InnerRadius, OuterRadius] 的所有点，InnerRadius 为 0 时退化为圆。
+type Ring struct {
+	Center      Coord
+	InnerRadius int32
+	OuterRadius int32
+}
+
+// NewRing 以圆心与内外半径创建圆环。
+func NewRing(center Coord, innerRadius, outerRadius int32) Ring {
+	return Ring{
+		Center:      center,
+		InnerRadius: innerRadius,
+		OuterRadius: outerRadius,
+	}
+}
+
+// IsCoordInside 判断点是否在圆环内（含内外边界），比较距离平方避免开方。
+func (r *Ring) IsCoordInside(p Coord) bool {
+	dst := CalDstCoordToCoordWithoutSqrt(r.Center, p)
+	return dst >= float64(r.InnerRadius)*float64(r.InnerRadius) &&
+		dst <= float64(r.OuterRadius)*float64(r.OuterRadius)
+}
+
+// ToRect 返回圆环的轴对齐包围盒，即外圆的包围盒。
+func (r *Ring) ToRect() (minX, minZ, maxX, maxZ int32) {
+	outer := NewCirCle(r.Center, r.OuterRadius)
+	return outer.ToRect()
+}
+
+// GetLocationToBorder 获取圆环包围盒与给定边界的象限重叠关系。
+func (r *Ring) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := r.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// GetBoundaryCoords 由 GetArcCoords 采样圆环的外圈与内圈（首尾不重复）。
+// 外圈按逆时针排列，内圈按顺时针排列，符合带洞多边形的环向约定；内半径为 0 时内圈为 nil。
+func (r *Ring) GetBoundaryCoords() (outer, inner []Coord) {
+	outer = GetArcCoords(Coord{X: r.Center.X + r.OuterRadius, Z: r.Center.Z}, r.Center, -2*math.Pi)
+	outer = outer[:len(outer)-1]
+	if r.InnerRadius > 0 {
+		inner = GetArcCoords(Coord{X: r.Center.X + r.InnerRadius, Z: r.Center.Z}, r.Center, 2*math.Pi)
+		inner = inner[:len(inner)-1]
+	}
+	return outer, inner
+}
+
+// IntersectCircle 判断圆环与圆是否相交（含接触）：圆与外圆重叠，且圆未完全落在内圈的空洞中。
+func (r *Ring) IntersectCircle(c Circle) bool {
+	dst := CalDstCoordToCoord(r.Center, c.Center)
+	return dst <= float64(r.OuterRadius)+float64(c.Radius) &&
+		dst+float64(c.Radius) >= float64(r.InnerRadius)
+}
+
+// IntersectSegment 判断圆环与线段是否相交（含接触）。
+// 线段上的点到圆心的距离连续变化，取值区间为 [到线段的最短距离, 较远端点的距离]，
+// 该区间与 [InnerRadius, OuterRadius] 有交集即相交。
+func (r *Ring) IntersectSegment(s Segment) bool {
+	nearest := s.DistanceToPoint(r.Center)
+	farthest := max(CalDstCoordToCoord(r.Center, s.A), CalDstCoordToCoord(r.Center, s.B))
+	return r.isRangeOverlap(nearest, farthest)
+}
+
+// IntersectConvex 判断圆环与凸多边形是否相交（含接触），顶点顺序不限。
+// 与 IntersectSegment 同理：圆心在多边形内时最短距离为 0，否则取到各边的最短距离；最远距离取自顶点。
+func (r *Ring) IntersectConvex(vectors []Vector) bool {
+	if len(vectors) < 3 {
+		return false
+	}
+	nearest := math.MaxFloat64
+	if isCoordInConvexVectors(vectors, r.Center) {
+		nearest = 0
+	}
+	farthest := 0.0
+	for i := range vectors {
+		seg := NewSegment(Coord(vectors[i]), Coord(vectors[(i+1)%len(vectors)]))
+		nearest = min(nearest, seg.DistanceToPoint(r.Center))
+		farthest = max(farthest, CalDstCoordToCoord(r.Center, Coord(vectors[i])))
+	}
+	return r.isRangeOverlap(nearest, farthest)
+}
+
+// isRangeOverlap 判断距离区间 [nearest, farthest] 与 [InnerRadius, OuterRadius] 是否有交集。
+func (r *Ring) isRangeOverlap(nearest, farthest float64) bool {
+	return nearest <= float64(r.OuterRadius) && farthest >= float64(r.InnerRadius)
+}
@@ -0,0 +1,190 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ErrOutOfRange 表示取整结果超出目标整数类型的范围（或为 NaN），结果已饱和到边界值
+var ErrOutOfRange = errors.New("geo: value out of integer range")
+
+// RoundingMode 表示计算结果（浮点数或有理数）转换为 int32 坐标时的取整方式。
+type RoundingMode int8
+
+const (
+	RoundTruncate RoundingMode = iota // 向零截断，与 Go 的 int32(x) 转换一致（默认，兼容历史行为）
+	RoundHalfEven                     // 四舍六入五成双（银行家舍入），无方向偏差
+	RoundFloor                        // 向负无穷取整，正负坐标上的偏差方向一致
+	RoundCeil                         // 向正无穷取整，与 RoundFloor 配合用于向外扩展的包围盒等有向取整
+)
+
+// Rounding 为包级取整方式，Vector.Trunc 与 Vector.Rotate 的结果分量，Segment.ClosestPoint、
+// GetCrossCoord、Circle.GetLineCross 中交点相对线段起点的偏移量，多边形偏移的顶点位移
+// 以及 SimplePolygon.Centroid 均按此方式取整；包围盒、推离向量等需要向外取整的结果固定使用 RoundFloor / RoundCeil。
+// 默认的 RoundTruncate 与历史行为一致，但负值向正方向偏移、正值向负方向偏移，偏差方向随坐标符号变化，
+// 对精度敏感的场景建议改用 RoundHalfEven 或 RoundFloor。应在程序初始化时设置，运行期间修改不是并发安全的。
+var Rounding = RoundTruncate
+
+// OnRoundOverflow 为可选的溢出回调：取整结果超出 int32 范围（或为 NaN）时，
+// 结果饱和到 math.MaxInt32 / math.MinInt32（NaN 取 0），并以 ErrOutOfRange 包装的错误调用该回调。
+// 默认为 nil，即静默饱和；与 Rounding 一样应在初始化时设置。
+var OnRoundOverflow func(err error)
+
+// Round 按取整方式将 f 转换为 int32，超出范围时饱和到 int32 的边界而不是回绕，NaN 转换为 0。
+// 超出范围时若设置了 OnRoundOverflow 则调用之。
+func (m RoundingMode) Round(f float64) int32 {
+	v, err := m.RoundChecked(f)
+	if err != nil && OnRoundOverflow != nil {
+		OnRoundOverflow(err)
+	}
+	return v
+}
+
+// RoundChecked 与 Round 相同，但超出 int32 范围或为 NaN 时额外返回 ErrOutOfRange 包装的错误，
+// 返回值仍为饱和后的结果。不会调用 OnRoundOverflow。
+func (m RoundingMode) RoundChecked(f float64) (int32, error) {
+	if math.IsNaN(f) {
+		return 0, fmt.Errorf("%w: NaN", ErrOutOfRange)
+	}
+	f = m.apply(f)
+	switch {
+	case f > math.MaxInt32:
+		return math.MaxInt32, fmt.Errorf("%w: %g", ErrOutOfRange, f)
+	case f < math.MinInt32:
+		return math.MinInt32, fmt.Errorf("%w: %g", ErrOutOfRange, f)
+	}
+	return int32(f), nil
+}
+
+// roundInt64Checked 与 RoundChecked 相同，但目标类型为 int64。
+func (m RoundingMode) roundInt64Checked(f float64) (int64, error) {
+	if math.IsNaN(f) {
+		return 0, fmt.Errorf("%w: NaN", ErrOutOfRange)
+	}
+	// float64 能表示的 int64 上界为 2^63 - 1024，2^63 本身已越界
+	switch f = m.apply(f); {
+	case f >= 0x1p63:
+		return math.MaxInt64, fmt.Errorf("%w: %g", ErrOutOfRange, f)
+	case f < -0x1p63:
+		return math.MinInt64, fmt.Errorf("%w: %g", ErrOutOfRange, f)
+	}
+	return int64(f), nil
+}
+
+// apply 按取整方式将 f 取整为整数值（仍以 float64 表示）。
+func (m RoundingMode) apply(f float64) float64 {
+	switch m {
+	case RoundHalfEven:
+		return math.RoundToEven(f)
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeil:
+		return math.Ceil(f)
+	default:
+		return math.Trunc(f)
+	}
+}
+
+// String 返回取整方式的名称。
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundTruncate:
+		return "Truncate"
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundFloor:
+		return "Floor"
+	case RoundCeil:
+		return "Ceil"
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int8(m))
+}
+
+// roundInt32 按包级取整方式 Rounding 将 f 转换为 int32。
+func roundInt32(f float64) int32 {
+	return Rounding.Round(f)
+}
+
+// roundQuo 按包级取整方式精确计算 num/den 并转换为 int32，den 不为 0。
+// 分子分母均为整数时不经过浮点，各取整方式的结果都是精确的。
+func roundQuo(num, den int64) int32 {
+	if den < 0 {
+		num, den = -num, -den
+	}
+	// 向负无穷整除，余数 r 落在 [0, den)
+	q, r := num/den, num%den
+	if r < 0 {
+		q, r = q-1, r+den
+	}
+	return saturateInt32(Rounding.roundFraction(q, r != 0, cmpHalf(r, den)))
+}
+
+// roundQuoBig 是 roundQuo 的 math/big 版本，用于分子超出 int64 范围的情形。
+func roundQuoBig(num, den *big.Int) int32 {
+	num, den = new(big.Int).Set(num), new(big.Int).Set(den)
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	// den 为正时 DivMod 即向负无穷整除，余数落在 [0, den)
+	q, r := new(big.Int).DivMod(num, den, new(big.Int))
+	if !q.IsInt64() {
+		return saturateInt32(int64(q.Sign()) * math.MaxInt64)
+	}
+	return saturateInt32(Rounding.roundFraction(q.Int64(), r.Sign() != 0, new(big.Int).Lsh(r, 1).Cmp(den)))
+}
+
+// cmpHalf 比较 2r 与 den（0 <= r < den），返回 -1、0、1。
+func cmpHalf(r, den int64) int {
+	// 以 r 与 den-r 比较代替 2r，避免溢出
+	switch d := r - (den - r); {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	}
+	return 0
+}
+
+// roundFraction 按取整方式对 floor + 小数部分 取整，其中 floor 为向负无穷取整的整数部分，
+// frac 表示小数部分是否非零，half 为小数部分与 0.5 的比较结果。
+func (m RoundingMode) roundFraction(floor int64, frac bool, half int) int64 {
+	if !frac {
+		return floor
+	}
+	switch m {
+	case RoundHalfEven:
+		if half > 0 || (half == 0 && floor%2 != 0) {
+			return floor + 1
+		}
+		return floor
+	case RoundFloor:
+		return floor
+	case RoundCeil:
+		return floor + 1
+	default:
+		// 向零截断：负数（floor < 0）带小数时向上取整
+		if floor < 0 {
+			return floor + 1
+		}
+		return floor
+	}
+}
+
+// saturateInt32 将 int64 饱和到 int32 范围，溢出时调用 OnRoundOverflow。
+func saturateInt32(v int64) int32 {
+	if v > math.MaxInt32 || v < math.MinInt32 {
+		if OnRoundOverflow != nil {
+			OnRoundOverflow(fmt.Errorf("%w: %d", ErrOutOfRange, v))
+		}
+		return int32(min(max(v, math.MinInt32), math.MaxInt32))
+	}
+	return int32(v)
+}
+
+// addInt32 计算 a + b，结果超出 int32 范围时饱和（同 saturateInt32），用于坐标加上取整后的偏移量。
+func addInt32(a, b int32) int32 {
+	return saturateInt32(int64(a) + int64(b))
+}
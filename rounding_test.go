@@ -0,0 +1,184 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// setRounding 在测试期间修改包级取整方式与溢出回调，测试结束后恢复默认值。
+func setRounding(t *testing.T, m RoundingMode, onOverflow func(error)) {
+	t.Helper()
+	Rounding, OnRoundOverflow = m, onOverflow
+	t.Cleanup(func() {
+		Rounding, OnRoundOverflow = RoundTruncate, nil
+	})
+}
+
+func TestRoundingModeRound(t *testing.T) {
+	modes := []RoundingMode{RoundTruncate, RoundHalfEven, RoundFloor, RoundCeil}
+	tests := []struct {
+		name string
+		f    float64
+		want [4]int32 // 依次为 Truncate、HalfEven、Floor、Ceil
+	}{
+		{"integer", 3, [4]int32{3, 3, 3, 3}},
+		{"positive half even", 2.5, [4]int32{2, 2, 2, 3}},
+		{"positive half odd", 3.5, [4]int32{3, 4, 3, 4}},
+		{"negative half", -2.5, [4]int32{-2, -2, -3, -2}},
+		{"negative fraction", -2.7, [4]int32{-2, -3, -3, -2}},
+		{"positive fraction", 2.7, [4]int32{2, 3, 2, 3}},
+		{"small negative", -0.2, [4]int32{0, 0, -1, 0}},
+		{"upper bound", math.MaxInt32 + 0.4, [4]int32{math.MaxInt32, math.MaxInt32, math.MaxInt32, math.MaxInt32}},
+		{"saturates high", 1e20, [4]int32{math.MaxInt32, math.MaxInt32, math.MaxInt32, math.MaxInt32}},
+		{"saturates low", -1e12, [4]int32{math.MinInt32, math.MinInt32, math.MinInt32, math.MinInt32}},
+		{"NaN", math.NaN(), [4]int32{0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, m := range modes {
+				if got := m.Round(tt.f); got != tt.want[i] {
+					t.Fatalf("%v.Round(%v) = %d, want %d", m, tt.f, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundingModeRoundChecked(t *testing.T) {
+	tests := []struct {
+		name string
+		f    float64
+		want int32
+		err  bool
+	}{
+		{"in range", -7.9, -8, false},
+		{"max", math.MaxInt32, math.MaxInt32, false},
+		{"floor of min", math.MinInt32 + 0.5, math.MinInt32, false},
+		{"too large", 1e20, math.MaxInt32, true},
+		{"floor below min", math.MinInt32 - 0.5, math.MinInt32, true},
+		{"positive infinity", math.Inf(1), math.MaxInt32, true},
+		{"NaN", math.NaN(), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundFloor.RoundChecked(tt.f)
+			if got != tt.want || (err != nil) != tt.err || err != nil && !errors.Is(err, ErrOutOfRange) {
+				t.Fatalf("RoundChecked(%v) = %d, %v, want %d, error %v", tt.f, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestOnRoundOverflow(t *testing.T) {
+	var got []error
+	setRounding(t, RoundTruncate, func(err error) { got = append(got, err) })
+	tests := []struct {
+		name     string
+		round    func() int32
+		want     int32
+		overflow bool
+	}{
+		{"Round in range", func() int32 { return RoundTruncate.Round(12.5) }, 12, false},
+		{"Round below min", func() int32 { return RoundTruncate.Round(-1e12) }, math.MinInt32, true},
+		{"RoundChecked does not call back", func() int32 { v, _ := RoundTruncate.RoundChecked(1e12); return v }, math.MaxInt32, false},
+		{"addInt32 saturates", func() int32 { return addInt32(math.MaxInt32, 1) }, math.MaxInt32, true},
+		{"roundQuo saturates", func() int32 { return roundQuo(-1<<40, 3) }, math.MinInt32, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			if v := tt.round(); v != tt.want {
+				t.Fatalf("got %d, want %d", v, tt.want)
+			}
+			if (len(got) > 0) != tt.overflow || tt.overflow && !errors.Is(got[0], ErrOutOfRange) {
+				t.Fatalf("OnRoundOverflow called with %v, want overflow %v", got, tt.overflow)
+			}
+		})
+	}
+}
+
+// TestRoundQuoMatchesFloat 校验各取整方式下整数除法取整与浮点取整的结果一致。
+func TestRoundQuoMatchesFloat(t *testing.T) {
+	rng := rand.New(rand.NewPCG(31, 0))
+	for _, m := range []RoundingMode{RoundTruncate, RoundHalfEven, RoundFloor, RoundCeil} {
+		setRounding(t, m, nil)
+		for range 20000 {
+			num := rng.Int64N(2001) - 1000
+			den := rng.Int64N(41) - 20
+			if den == 0 {
+				continue
+			}
+			want := m.Round(float64(num) / float64(den))
+			if got := roundQuo(num, den); got != want {
+				t.Fatalf("%v: roundQuo(%d, %d) = %d, want %d", m, num, den, got, want)
+			}
+			if got := roundQuoBig(big.NewInt(num), big.NewInt(den)); got != want {
+				t.Fatalf("%v: roundQuoBig(%d, %d) = %d, want %d", m, num, den, got, want)
+			}
+		}
+	}
+}
+
+func TestRoundingAffectsResults(t *testing.T) {
+	v := Vector{X: -3, Z: 3}
+	tests := []struct {
+		mode RoundingMode
+		want Vector
+	}{
+		{RoundTruncate, Vector{X: -1, Z: 1}},
+		{RoundHalfEven, Vector{X: -2, Z: 2}},
+		{RoundFloor, Vector{X: -2, Z: 1}},
+		{RoundCeil, Vector{X: -1, Z: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			setRounding(t, tt.mode, nil)
+			if got := v.Trunc(0.5); got != tt.want {
+				t.Fatalf("Trunc(0.5) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCentroidFallbackRounding 校验面积为 0 时顶点平均值同样按包级取整方式取整。
+func TestCentroidFallbackRounding(t *testing.T) {
+	s := NewSimplePolygon(0, []Coord{{0, 0}, {-5, 0}, {-10, 0}, {-15, 0}}) // 平均值 (-7.5, 0)
+	tests := []struct {
+		mode RoundingMode
+		want Coord
+	}{
+		{RoundTruncate, Coord{-7, 0}},
+		{RoundHalfEven, Coord{-8, 0}},
+		{RoundFloor, Coord{-8, 0}},
+		{RoundCeil, Coord{-7, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			setRounding(t, tt.mode, nil)
+			if got := s.Centroid(); got != tt.want {
+				t.Fatalf("Centroid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundingModeString(t *testing.T) {
+	tests := []struct {
+		mode RoundingMode
+		want string
+	}{
+		{RoundTruncate, "Truncate"},
+		{RoundHalfEven, "HalfEven"},
+		{RoundFloor, "Floor"},
+		{RoundCeil, "Ceil"},
+		{RoundingMode(9), "RoundingMode(9)"},
+	}
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Fatalf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package geo
+
+import "math"
+
+// IntersectConvex 使用分离轴定理（SAT）判断两个凸多边形是否相交，并求出最小平移向量（MTV）。
+// 候选分离轴为两个多边形全部边的法向量；两者在某条轴上的投影区间不重叠即可判定分离。
+// 所有轴上的投影均重叠时两者相交，重叠量最小的轴即为推离方向：
+// 将 a 沿 mtv 平移后两者恰好分离（或仅边界接触），depth 为该轴上的穿透深度。
+//
+// 顶点顺序（顺时针或逆时针）不影响结果，可直接传入 Rectangle.GetVectors、Convex.GetVectors 等的返回值。
+// 边界接触视为相交，此时 depth 为 0、mtv 为零向量。mtv 分量向远离零的方向取整，
+// 保证按整数坐标平移后不会残留穿透。任一多边形少于 3 个顶点时返回 false。
+func IntersectConvex(a, b []Vector) (bool, Vector, float64) {
+	if len(a) < 3 || len(b) < 3 {
+		return false, Vector{}, 0
+	}
+	best := math.MaxFloat64
+	var axis [2]float64
+	for _, poly := range [2][]Vector{a, b} {
+		for i := range poly {
+			n, ok := edgeNormal(poly[i], poly[(i+1)%len(poly)])
+			if !ok {
+				continue
+			}
+			minA, maxA := projectVectors(a, n)
+			minB, maxB := projectVectors(b, n)
+			depth, dir, ok := overlap(minA, maxA, minB, maxB)
+			if !ok {
+				return false, Vector{}, 0
+			}
+			if depth < best {
+				best = depth
+				axis = [2]float64{n[0] * dir, n[1] * dir}
+			}
+		}
+	}
+	return true, mtvVector(axis, best), best
+}
+
+// IntersectConvex 使用分离轴定理判断圆与凸多边形是否相交，并求出将圆推离多边形的最小平移向量。
+// 候选分离轴为多边形各边的法向量，以及圆心指向最近顶点的方向（覆盖圆与顶点区域接触的情形）。
+// 返回值含义与包级函数 IntersectConvex 一致：将圆沿 mtv 平移后两者恰好分离。
+// 与 IsInterPolygon 不同，顶点顺序不影响结果，但多边形必须为凸多边形。
+func (c *Circle) IntersectConvex(vectors []Vector) (bool, Vector, float64) {
+	if len(vectors) < 3 {
+		return false, Vector{}, 0
+	}
+	center := NewVectorByCoord(c.Center)
+	axes := make([][2]float64, 0, len(vectors)+1)
+	for i := range vectors {
+		if n, ok := edgeNormal(vectors[i], vectors[(i+1)%len(vectors)]); ok {
+			axes = append(axes, n)
+		}
+	}
+	nearest := vectors[0]
+	for _, v := range vectors[1:] {
+		if CalDstCoordToCoordWithoutSqrt(Coord(v), c.Center) < CalDstCoordToCoordWithoutSqrt(Coord(nearest), c.Center) {
+			nearest = v
+		}
+	}
+	if n, ok := edgeNormal(center, nearest); ok {
+		// 法向量绕 90° 还原为圆心→顶点方向
+		axes = append(axes, [2]float64{n[1], -n[0]})
+	}
+
+	best := math.MaxFloat64
+	var axis [2]float64
+	for _, n := range axes {
+		proj := float64(center.X)*n[0] + float64(center.Z)*n[1]
+		minA, maxA := proj-float64(c.Radius), proj+float64(c.Radius)
+		minB, maxB := projectVectors(vectors, n)
+		depth, dir, ok := overlap(minA, maxA, minB, maxB)
+		if !ok {
+			return false, Vector{}, 0
+		}
+		if depth < best {
+			best = depth
+			axis = [2]float64{n[0] * dir, n[1] * dir}
+		}
+	}
+	return true, mtvVector(axis, best), best
+}
+
+// edgeNormal 返回边 p→q 的单位法向量（边向量逆时针旋转 90°），退化边返回 false。
+func edgeNormal(p, q Vector) ([2]float64, bool) {
+	dx := float64(q.X) - float64(p.X)
+	dz := float64(q.Z) - float64(p.Z)
+	length := math.Hypot(dx, dz)
+	if length == 0 {
+		return [2]float64{}, false
+	}
+	return [2]float64{-dz / length, dx / length}, true
+}
+
+// projectVectors 返回顶点集在单位轴上的投影区间。
+func projectVectors(vectors []Vector, n [2]float64) (float64, float64) {
+	lo, hi := math.MaxFloat64, -math.MaxFloat64
+	for _, v := range vectors {
+		proj := float64(v.X)*n[0] + float64(v.Z)*n[1]
+		lo, hi = min(lo, proj), max(hi, proj)
+	}
+	return lo, hi
+}
+
+// overlap 计算区间 [minA, maxA] 相对 [minB, maxB] 的最小推离量及方向：
+// dir 为 1 表示 A 沿轴正方向移动，-1 表示沿负方向移动。区间不重叠时返回 false。
+// 同时比较两个方向的推离量，可正确处理一个区间包含另一个区间的情形。
+func overlap(minA, maxA, minB, maxB float64) (float64, float64, bool) {
+	if maxA < minB || maxB < minA {
+		return 0, 0, false
+	}
+	forward := maxB - minA
+	backward := maxA - minB
+	if forward < backward {
+		return forward, 1, true
+	}
+	return backward, -1, true
+}
+
+// mtvVector 将单位轴与深度转换为整数平移向量，各分量向远离零的方向取整。
+func mtvVector(axis [2]float64, depth float64) Vector {
+	roundOut := func(f float64) int32 {
+		// 消除浮点噪声后再向外取整，避免 3.0000000001 被取整为 4
+		f = math.Round(f*1e6) / 1e6
+		if f < 0 {
+			return RoundFloor.Round(f)
+		}
+		return RoundCeil.Round(f)
+	}
+	return Vector{X: roundOut(axis[0] * depth), Z: roundOut(axis[1] * depth)}
+}
@@ -0,0 +1,104 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestIntersectConvex(t *testing.T) {
+	square := func(x, z, w int32) []Vector {
+		return []Vector{{x, z}, {x + w, z}, {x + w, z + w}, {x, z + w}}
+	}
+	tests := []struct {
+		name  string
+		a, b  []Vector
+		hit   bool
+		mtv   Vector
+		depth float64
+	}{
+		{"separated", square(0, 0, 100), square(200, 0, 100), false, Vector{}, 0},
+		{"touching edge", square(0, 0, 100), square(100, 0, 100), true, Vector{}, 0},
+		{"overlap along X", square(0, 0, 100), square(80, 0, 100), true, Vector{-20, 0}, 20},
+		{"overlap along Z", square(0, 0, 100), square(0, 90, 100), true, Vector{0, -10}, 10},
+		{"contains other", square(0, 0, 100), square(10, 40, 20), true, Vector{30, 0}, 30},
+		{"diagonal axis", []Vector{{0, 0}, {100, 0}, {0, 100}}, square(40, 40, 100), true, Vector{-10, -10}, 20 / math.Sqrt2},
+		{"clockwise order", []Vector{{0, 100}, {100, 100}, {100, 0}, {0, 0}}, square(80, 0, 100), true, Vector{-20, 0}, 20},
+		{"degenerate polygon", []Vector{{0, 0}, {100, 0}}, square(0, 0, 100), false, Vector{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, mtv, depth := IntersectConvex(tt.a, tt.b)
+			if hit != tt.hit || mtv != tt.mtv || math.Abs(depth-tt.depth) > 1e-9 {
+				t.Fatalf("IntersectConvex() = %v, %v, %v, want %v, %v, %v", hit, mtv, depth, tt.hit, tt.mtv, tt.depth)
+			}
+		})
+	}
+}
+
+func TestCircleIntersectConvex(t *testing.T) {
+	square := []Vector{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	tests := []struct {
+		name  string
+		c     Circle
+		hit   bool
+		mtv   Vector
+		depth float64
+	}{
+		{"separated", NewCirCle(Coord{150, 50}, 40), false, Vector{}, 0},
+		{"touching edge", NewCirCle(Coord{140, 50}, 40), true, Vector{}, 0},
+		{"overlap edge", NewCirCle(Coord{130, 50}, 40), true, Vector{10, 0}, 10},
+		{"overlap corner", NewCirCle(Coord{110, 110}, 20), true, Vector{5, 5}, 20 - 10*math.Sqrt2},
+		{"outside corner region", NewCirCle(Coord{115, 115}, 20), false, Vector{}, 0},
+		{"center inside", NewCirCle(Coord{20, 50}, 10), true, Vector{-30, 0}, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, mtv, depth := tt.c.IntersectConvex(square)
+			if hit != tt.hit || mtv != tt.mtv || math.Abs(depth-tt.depth) > 1e-9 {
+				t.Fatalf("IntersectConvex() = %v, %v, %v, want %v, %v, %v", hit, mtv, depth, tt.hit, tt.mtv, tt.depth)
+			}
+		})
+	}
+}
+
+// TestIntersectConvexMTV 校验随机矩形与三角形沿 mtv 平移后不再穿透。
+func TestIntersectConvexMTV(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 0))
+	randConvex := func() []Vector {
+		r := NewRectangle(rng.Int32N(1000), rng.Int32N(1000), 50+rng.Int32N(300), 50+rng.Int32N(300))
+		v := r.GetVectors()
+		if rng.IntN(2) == 0 {
+			return v[:3]
+		}
+		return v[:]
+	}
+	shift := func(vs []Vector, d Vector) []Vector {
+		out := slices.Clone(vs)
+		for i := range out {
+			out[i] = out[i].Add(&d)
+		}
+		return out
+	}
+	hits := 0
+	for range 5000 {
+		a, b := randConvex(), randConvex()
+		if hit, mtv, _ := IntersectConvex(a, b); hit {
+			hits++
+			if h, _, d := IntersectConvex(shift(a, mtv), b); h && d > 1e-6 {
+				t.Fatalf("IntersectConvex() still overlaps %.3f after moving %v by %v", d, a, mtv)
+			}
+		}
+		c := NewCirCle(Coord{rng.Int32N(1400), rng.Int32N(1400)}, 10+rng.Int32N(200))
+		if hit, mtv, _ := c.IntersectConvex(b); hit {
+			moved := NewCirCle(Coord{c.Center.X + mtv.X, c.Center.Z + mtv.Z}, c.Radius)
+			if h, _, d := moved.IntersectConvex(b); h && d > 1e-6 {
+				t.Fatalf("Circle.IntersectConvex() still overlaps %.3f after moving %v by %v", d, c, mtv)
+			}
+		}
+	}
+	if hits < 100 {
+		t.Fatalf("only %d of 5000 random pairs overlap", hits)
+	}
+}
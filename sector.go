@@ -0,0 +1,226 @@
+package geo
+
+import "math"
+
+// Sector 表示扇形区域，由圆心、半径、朝向与半角定义，常用于锥形技能的命中判定。
+// 扇形覆盖与朝向夹角不超过 HalfAngle 的方向，HalfAngle >= π 时退化为整圆。
+type Sector struct {
+	Center    Coord
+	Radius    int32
+	Facing    Vector  // 朝向向量，只取方向，零向量视为全方向
+	HalfAngle float64 // 半角（弧度），范围 [0, π]
+}
+
+// NewSector 以圆心、半径、朝向与半角创建扇形。
+func NewSector(center Coord, radius int32, facing Vector, halfAngle float64) Sector {
+	return Sector{
+		Center:    center,
+		Radius:    radius,
+		Facing:    facing,
+		HalfAngle: halfAngle,
+	}
+}
+
+// IsCoordInside 判断点是否在扇形内（含边界）：到圆心距离不超过半径，且方向位于扇形张角内。
+func (s *Sector) IsCoordInside(p Coord) bool {
+	if CalDstCoordToCoordWithoutSqrt(s.Center, p) > float64(s.Radius)*float64(s.Radius) {
+		return false
+	}
+	return s.isCoordInWedge(p)
+}
+
+// ToRect 返回扇形的轴对齐包围盒。
+// 由圆心、两条半径边的端点以及落在张角内的坐标轴方向极值点共同确定，比外接圆的包围盒更紧凑。
+func (s *Sector) ToRect() (minX, minZ, maxX, maxZ int32) {
+	cx, cz := float64(s.Center.X), float64(s.Center.Z)
+	r := float64(s.Radius)
+	x0, z0, x1, z1 := cx, cz, cx, cz
+	expand := func(dx, dz float64) {
+		x0, z0 = min(x0, cx+dx), min(z0, cz+dz)
+		x1, z1 = max(x1, cx+dx), max(z1, cz+dz)
+	}
+	if s.isFull() {
+		expand(-r, -r)
+		expand(r, r)
+	} else {
+		base := math.Atan2(float64(s.Facing.Z), float64(s.Facing.X))
+		for _, a := range [2]float64{base - s.HalfAngle, base + s.HalfAngle} {
+			expand(r*math.Cos(a), r*math.Sin(a))
+		}
+		for _, d := range [4][2]float64{{r, 0}, {0, r}, {-r, 0}, {0, -r}} {
+			if s.isDirInWedge(d[0], d[1]) {
+				expand(d[0], d[1])
+			}
+		}
+	}
+	return RoundFloor.Round(x0), RoundFloor.Round(z0), RoundCeil.Round(x1), RoundCeil.Round(z1)
+}
+
+// GetLocationToBorder 获取扇形包围盒与给定边界的象限重叠关系。
+func (s *Sector) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := s.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// GetEdgeCoords 返回两条半径边在圆周上的端点：right 为朝向顺时针旋转半角所得，left 为逆时针旋转所得。
+func (s *Sector) GetEdgeCoords() (right, left Coord) {
+	length := s.Facing.Length()
+	if length == 0 {
+		p := Coord{X: s.Center.X + s.Radius, Z: s.Center.Z}
+		return p, p
+	}
+	base := s.Facing.Trunc(float64(s.Radius) / length)
+	r := base.Rotate(-s.HalfAngle)
+	l := base.Rotate(s.HalfAngle)
+	return r.ToCoord(s.Center), l.ToCoord(s.Center)
+}
+
+// GetBoundaryCoords 采样扇形边界，返回按逆时针排列的闭合轮廓点集（首尾不重复）。
+// 轮廓以圆心开始，经右侧端点沿圆弧（由 GetArcCoords 采样）到达左侧端点；整圆时仅包含圆弧采样点。
+func (s *Sector) GetBoundaryCoords() []Coord {
+	right, _ := s.GetEdgeCoords()
+	if s.isFull() {
+		arc := GetArcCoords(right, s.Center, -2*math.Pi)
+		return arc[:len(arc)-1]
+	}
+	arc := GetArcCoords(right, s.Center, -2*s.HalfAngle)
+	return append([]Coord{s.Center}, arc...)
+}
+
+// IntersectCircle 判断扇形与圆是否相交（含接触）。
+// 圆心位于扇形张角内时，只需比较圆心距与两半径之和；
+// 否则扇形上距圆心最近的点必在两条半径边上，比较圆心到半径边的距离与圆半径。
+func (s *Sector) IntersectCircle(c Circle) bool {
+	if s.isCoordInWedge(c.Center) {
+		return CalDstCoordToCoord(s.Center, c.Center) <= float64(s.Radius)+float64(c.Radius)
+	}
+	right, left := s.GetEdgeCoords()
+	for _, p := range [2]Coord{right, left} {
+		seg := NewSegment(s.Center, p)
+		if seg.DistanceToPoint(c.Center) <= float64(c.Radius) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectSegment 判断扇形与线段是否相交（含接触）：
+// 端点在扇形内、与任一半径边相交，或与圆弧存在位于张角内的交点。
+func (s *Sector) IntersectSegment(seg Segment) bool {
+	if s.IsCoordInside(seg.A) || s.IsCoordInside(seg.B) {
+		return true
+	}
+	if !s.isFull() {
+		right, left := s.GetEdgeCoords()
+		for _, p := range [2]Coord{right, left} {
+			if isSegmentCross(seg.A, seg.B, s.Center, p) {
+				return true
+			}
+		}
+	}
+	// 线段参数方程 A + t(B-A) 与圆方程联立，逐个检查落在线段上的根是否在张角内
+	dx, dz := float64(seg.B.X)-float64(seg.A.X), float64(seg.B.Z)-float64(seg.A.Z)
+	ex, ez := float64(seg.A.X)-float64(s.Center.X), float64(seg.A.Z)-float64(s.Center.Z)
+	a := dx*dx + dz*dz
+	b := 2 * (dx*ex + dz*ez)
+	c := ex*ex + ez*ez - float64(s.Radius)*float64(s.Radius)
+	disc := b*b - 4*a*c
+	if a == 0 || disc < 0 {
+		return false
+	}
+	sqrt := math.Sqrt(disc)
+	for _, t := range [2]float64{(-b - sqrt) / (2 * a), (-b + sqrt) / (2 * a)} {
+		if t >= 0 && t <= 1 && s.isDirInWedge(ex+t*dx, ez+t*dz) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectConvex 判断扇形与凸多边形是否相交（含接触），顶点顺序不限。
+// 两者重叠时，要么多边形的某条边与扇形相交，要么扇形完全位于多边形内（圆心在多边形内）。
+func (s *Sector) IntersectConvex(vectors []Vector) bool {
+	if len(vectors) < 3 {
+		return false
+	}
+	if isCoordInConvexVectors(vectors, s.Center) {
+		return true
+	}
+	for i := range vectors {
+		seg := NewSegment(Coord(vectors[i]), Coord(vectors[(i+1)%len(vectors)]))
+		if s.IntersectSegment(seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFull 判断扇形是否退化为整圆。
+func (s *Sector) isFull() bool {
+	return s.HalfAngle >= math.Pi || (s.Facing.X == 0 && s.Facing.Z == 0)
+}
+
+// isCoordInWedge 判断点是否位于扇形张角所覆盖的无限锥形区域内，圆心本身视为在内。
+func (s *Sector) isCoordInWedge(p Coord) bool {
+	if p == s.Center {
+		return true
+	}
+	return s.isDirInWedge(float64(p.X)-float64(s.Center.X), float64(p.Z)-float64(s.Center.Z))
+}
+
+// isDirInWedge 判断方向 (dx, dz) 与朝向的夹角是否不超过半角。
+func (s *Sector) isDirInWedge(dx, dz float64) bool {
+	if s.isFull() {
+		return true
+	}
+	length := math.Hypot(dx, dz) * s.Facing.Length()
+	if length == 0 {
+		return true
+	}
+	cos := (dx*float64(s.Facing.X) + dz*float64(s.Facing.Z)) / length
+	return math.Acos(max(-1, min(1, cos))) <= s.HalfAngle+1e-9
+}
+
+// isSegmentCross 判断两条线段是否有公共点（含端点接触与共线重叠），对退化为点的线段同样成立。
+// 与 IsLineSegmentCross 不同，端点落在另一线段延长线上但不在线段内时不视为相交：
+// 四个方向符号严格异号时跨立相交，方向为 0 时再检查该端点是否落在另一线段的包围盒内。
+func isSegmentCross(p0, p1, q0, q1 Coord) bool {
+	d1 := orient(q0, q1, p0)
+	d2 := orient(q0, q1, p1)
+	d3 := orient(p0, p1, q0)
+	d4 := orient(p0, p1, q1)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	onSegment := func(a, b, p Coord) bool {
+		return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) && min(a.Z, b.Z) <= p.Z && p.Z <= max(a.Z, b.Z)
+	}
+	return (d1 == 0 && onSegment(q0, q1, p0)) ||
+		(d2 == 0 && onSegment(q0, q1, p1)) ||
+		(d3 == 0 && onSegment(p0, p1, q0)) ||
+		(d4 == 0 && onSegment(p0, p1, q1))
+}
+
+// isCoordInConvexVectors 判断点是否在凸多边形内（含边界），顶点顺序不限：
+// 点与各边的叉积符号一致（或为 0）即在内部。顶点退化为线段（2 个）或点（1 个）时判断点是否在其上。
+func isCoordInConvexVectors(vectors []Vector, p Coord) bool {
+	switch len(vectors) {
+	case 0:
+		return false
+	case 1:
+		return Coord(vectors[0]) == p
+	case 2:
+		a, b := Coord(vectors[0]), Coord(vectors[1])
+		return Orient2D(a, b, p) == 0 && isCoordInRect(a, b, p)
+	}
+	var pos, neg bool
+	for i := range vectors {
+		c := cross(Coord(vectors[i]), Coord(vectors[(i+1)%len(vectors)]), p)
+		pos = pos || c > 0
+		neg = neg || c < 0
+		if pos && neg {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// testSector 为朝向 X 轴正方向、半角 45°、半径 100 的扇形。
+var testSector = NewSector(Coord{0, 0}, 100, Vector{1, 0}, math.Pi/4)
+
+// squareVectors 返回左下角为 (x, z)、边长为 w 的逆时针正方形顶点。
+func squareVectors(x, z, w int32) []Vector {
+	return []Vector{{x, z}, {x + w, z}, {x + w, z + w}, {x, z + w}}
+}
+
+func TestSector(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func(s *Sector) bool
+		want bool
+	}{
+		{"center inside", func(s *Sector) bool { return s.IsCoordInside(Coord{0, 0}) }, true},
+		{"inside wedge", func(s *Sector) bool { return s.IsCoordInside(Coord{50, 40}) }, true},
+		{"outside wedge", func(s *Sector) bool { return s.IsCoordInside(Coord{50, 60}) }, false},
+		{"on arc", func(s *Sector) bool { return s.IsCoordInside(Coord{100, 0}) }, true},
+		{"beyond arc", func(s *Sector) bool { return s.IsCoordInside(Coord{101, 0}) }, false},
+		{"behind center", func(s *Sector) bool { return s.IsCoordInside(Coord{-10, 0}) }, false},
+		{"circle touching arc", func(s *Sector) bool { return s.IntersectCircle(NewCirCle(Coord{150, 0}, 50)) }, true},
+		{"circle beyond arc", func(s *Sector) bool { return s.IntersectCircle(NewCirCle(Coord{150, 0}, 49)) }, false},
+		{"circle touching center", func(s *Sector) bool { return s.IntersectCircle(NewCirCle(Coord{-30, 0}, 30)) }, true},
+		{"circle behind center", func(s *Sector) bool { return s.IntersectCircle(NewCirCle(Coord{-30, 0}, 29)) }, false},
+		{"circle near edge", func(s *Sector) bool { return s.IntersectCircle(NewCirCle(Coord{0, 100}, 71)) }, true},
+		{"circle off edge", func(s *Sector) bool { return s.IntersectCircle(NewCirCle(Coord{0, 100}, 70)) }, false},
+		{"segment across wedge", func(s *Sector) bool { return s.IntersectSegment(NewSegment(Coord{50, -100}, Coord{50, 100})) }, true},
+		{"segment inside", func(s *Sector) bool { return s.IntersectSegment(NewSegment(Coord{10, 0}, Coord{20, 0})) }, true},
+		{"segment behind", func(s *Sector) bool { return s.IntersectSegment(NewSegment(Coord{-50, -100}, Coord{-50, 100})) }, false},
+		{"segment beyond arc", func(s *Sector) bool { return s.IntersectSegment(NewSegment(Coord{120, -10}, Coord{120, 10})) }, false},
+		{"segment outside wedge", func(s *Sector) bool { return s.IntersectSegment(NewSegment(Coord{0, 50}, Coord{20, 100})) }, false},
+		{"convex across arc", func(s *Sector) bool { return s.IntersectConvex(squareVectors(90, -5, 10)) }, true},
+		{"convex containing sector", func(s *Sector) bool { return s.IntersectConvex(squareVectors(-200, -200, 400)) }, true},
+		{"convex behind", func(s *Sector) bool { return s.IntersectConvex(squareVectors(-30, -10, 20)) }, false},
+		{"convex outside wedge", func(s *Sector) bool { return s.IntersectConvex(squareVectors(20, 60, 10)) }, false},
+		{"full circle", func(*Sector) bool {
+			s := NewSector(Coord{0, 0}, 100, Vector{1, 0}, math.Pi)
+			return s.IsCoordInside(Coord{-100, 0})
+		}, true},
+		{"zero facing is full circle", func(*Sector) bool {
+			s := NewSector(Coord{0, 0}, 100, Vector{}, math.Pi/4)
+			return s.IsCoordInside(Coord{0, -100})
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSector
+			if got := tt.got(&s); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectorToRect(t *testing.T) {
+	tests := []struct {
+		name                   string
+		s                      Sector
+		minX, minZ, maxX, maxZ int32
+	}{
+		{"facing X", testSector, 0, -71, 100, 71},
+		{"facing -Z", NewSector(Coord{10, 10}, 100, Vector{0, -5}, math.Pi/2), -90, -90, 110, 10},
+		{"full circle", NewSector(Coord{10, 10}, 100, Vector{1, 0}, math.Pi), -90, -90, 110, 110},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minX, minZ, maxX, maxZ := tt.s.ToRect()
+			if minX != tt.minX || minZ != tt.minZ || maxX != tt.maxX || maxZ != tt.maxZ {
+				t.Fatalf("ToRect() = %d, %d, %d, %d, want %d, %d, %d, %d", minX, minZ, maxX, maxZ, tt.minX, tt.minZ, tt.maxX, tt.maxZ)
+			}
+		})
+	}
+}
+
+func TestRing(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func(r *Ring) bool
+		want bool
+	}{
+		{"center in hole", func(r *Ring) bool { return r.IsCoordInside(Coord{0, 0}) }, false},
+		{"inner border", func(r *Ring) bool { return r.IsCoordInside(Coord{50, 0}) }, true},
+		{"between borders", func(r *Ring) bool { return r.IsCoordInside(Coord{0, -75}) }, true},
+		{"outer border", func(r *Ring) bool { return r.IsCoordInside(Coord{100, 0}) }, true},
+		{"beyond outer", func(r *Ring) bool { return r.IsCoordInside(Coord{101, 0}) }, false},
+		{"circle in hole", func(r *Ring) bool { return r.IntersectCircle(NewCirCle(Coord{0, 0}, 49)) }, false},
+		{"circle touching inner", func(r *Ring) bool { return r.IntersectCircle(NewCirCle(Coord{0, 0}, 50)) }, true},
+		{"circle touching outer", func(r *Ring) bool { return r.IntersectCircle(NewCirCle(Coord{150, 0}, 50)) }, true},
+		{"circle outside", func(r *Ring) bool { return r.IntersectCircle(NewCirCle(Coord{150, 0}, 49)) }, false},
+		{"segment in hole", func(r *Ring) bool { return r.IntersectSegment(NewSegment(Coord{-20, -20}, Coord{20, 20})) }, false},
+		{"segment across ring", func(r *Ring) bool { return r.IntersectSegment(NewSegment(Coord{0, 0}, Coord{200, 0})) }, true},
+		{"segment outside", func(r *Ring) bool { return r.IntersectSegment(NewSegment(Coord{-200, 120}, Coord{200, 120})) }, false},
+		{"convex in hole", func(r *Ring) bool { return r.IntersectConvex(squareVectors(-20, -20, 40)) }, false},
+		{"convex containing ring", func(r *Ring) bool { return r.IntersectConvex(squareVectors(-200, -200, 400)) }, true},
+		{"convex across outer", func(r *Ring) bool { return r.IntersectConvex(squareVectors(90, -5, 20)) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRing(Coord{0, 0}, 50, 100)
+			if got := tt.got(&r); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSectorRingMatchesSampling 以逐点采样校验随机扇形、圆环的相交判定不会漏判，
+// 并检查包围盒覆盖全部内部点、边界轮廓方向正确。
+func TestSectorRingMatchesSampling(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 0))
+	for range 100 {
+		s := NewSector(Coord{rng.Int32N(100), rng.Int32N(100)}, 20+rng.Int32N(40), Vector{rng.Int32N(21) - 10, rng.Int32N(21) - 10}, rng.Float64()*math.Pi)
+		ring := NewRing(Coord{rng.Int32N(100), rng.Int32N(100)}, rng.Int32N(30), 30+rng.Int32N(30))
+		c := NewCirCle(Coord{rng.Int32N(100), rng.Int32N(100)}, 1+rng.Int32N(30))
+		rect := NewRectangle(rng.Int32N(100), rng.Int32N(100), 1+rng.Int32N(40), 1+rng.Int32N(40))
+		vs := rect.GetVectors()
+		x0, z0, x1, z1 := s.ToRect()
+		var sc, sr, rc, rr bool
+		for x := int32(-80); x < 200; x++ {
+			for z := int32(-80); z < 200; z++ {
+				p := Coord{x, z}
+				inS, inRing := s.IsCoordInside(p), ring.IsCoordInside(p)
+				if inS && (x < x0 || x > x1 || z < z0 || z > z1) {
+					t.Fatalf("%v ToRect() = %d, %d, %d, %d misses %v", s, x0, z0, x1, z1, p)
+				}
+				inC := CalDstCoordToCoordWithoutSqrt(p, c.Center) <= float64(c.Radius)*float64(c.Radius)
+				inR := rect.IsCoordInside(p)
+				sc, sr = sc || inS && inC, sr || inS && inR
+				rc, rr = rc || inRing && inC, rr || inRing && inR
+			}
+		}
+		if sc && !s.IntersectCircle(c) || sr && !s.IntersectConvex(vs[:]) {
+			t.Fatalf("sector %v misses circle %v or rect %v", s, c, rect)
+		}
+		if rc && !ring.IntersectCircle(c) || rr && !ring.IntersectConvex(vs[:]) {
+			t.Fatalf("ring %v misses circle %v or rect %v", ring, c, rect)
+		}
+		if b := s.GetBoundaryCoords(); signedArea2(b) < 0 || !s.isFull() && b[0] != s.Center {
+			t.Fatalf("sector %v boundary %v is not counter-clockwise from the center", s, b)
+		}
+		if o, in := ring.GetBoundaryCoords(); signedArea2(o) < 0 || in != nil && signedArea2(in) > 0 {
+			t.Fatalf("ring %v boundary has wrong orientation", ring)
+		}
+	}
+}
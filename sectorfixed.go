@@ -0,0 +1,223 @@
+package geo
+
+import (
+	"math"
+	"math/big"
+)
+
+// sectorDirScale 为 SectorFixed 张角边界方向向量的长度，取较大值以减小旋转取整带来的方向误差。
+const sectorDirScale = 1 << 16
+
+// SectorFixed 是 Sector 的定点版本，半角以 Angle 表示，供帧同步逻辑使用。
+// 边界方向由朝向经 RotateFixed 旋转半角得到，张角判定与距离比较全部为整数运算，
+// 不调用 math.Atan2、math.Cos、math.Acos 等超越函数，相同输入在任何平台上得到相同结果。
+// HalfAngle >= AngleHalf 或朝向为零向量时退化为整圆。
+type SectorFixed struct {
+	Center    Coord
+	Radius    int32
+	Facing    Vector // 朝向向量，只取方向，零向量视为全方向
+	HalfAngle Angle  // 半角，范围 [0, AngleHalf]
+}
+
+// NewSectorFixed 以圆心、半径、朝向与定点半角创建扇形。
+func NewSectorFixed(center Coord, radius int32, facing Vector, halfAngle Angle) SectorFixed {
+	return SectorFixed{
+		Center:    center,
+		Radius:    radius,
+		Facing:    facing,
+		HalfAngle: halfAngle,
+	}
+}
+
+// IsCoordInside 判断点是否在扇形内（含边界），距离以整数平方精确比较。
+func (s *SectorFixed) IsCoordInside(p Coord) bool {
+	return isWithinDist(s.Center, p, int64(s.Radius)) && s.isCoordInWedge(p)
+}
+
+// ToRect 返回扇形的轴对齐包围盒，由圆心、两条半径边的端点以及落在张角内的坐标轴方向极值点共同确定，
+// 超出 int32 范围的分量饱和到边界值。
+func (s *SectorFixed) ToRect() (minX, minZ, maxX, maxZ int32) {
+	cx, cz, r := int64(s.Center.X), int64(s.Center.Z), int64(s.Radius)
+	x0, z0, x1, z1 := cx, cz, cx, cz
+	expand := func(dx, dz int64) {
+		x0, z0 = min(x0, cx+dx), min(z0, cz+dz)
+		x1, z1 = max(x1, cx+dx), max(z1, cz+dz)
+	}
+	if s.isFull() {
+		expand(-r, -r)
+		expand(r, r)
+	} else {
+		right, left := s.GetEdgeCoords()
+		for _, p := range [2]Coord{right, left} {
+			expand(int64(p.X)-cx, int64(p.Z)-cz)
+		}
+		for _, d := range [4][2]int64{{r, 0}, {0, r}, {-r, 0}, {0, -r}} {
+			if s.isDirInWedge(d[0], d[1]) {
+				expand(d[0], d[1])
+			}
+		}
+	}
+	clamp := func(v int64) int32 {
+		return int32(min(max(v, math.MinInt32), math.MaxInt32))
+	}
+	return clamp(x0), clamp(z0), clamp(x1), clamp(z1)
+}
+
+// GetLocationToBorder 获取扇形包围盒与给定边界的象限重叠关系。
+func (s *SectorFixed) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := s.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// GetEdgeCoords 返回两条半径边在圆周上的端点，含义同 Sector.GetEdgeCoords，旋转由 RotateFixed 完成。
+func (s *SectorFixed) GetEdgeCoords() (right, left Coord) {
+	if s.Facing.X == 0 && s.Facing.Z == 0 {
+		p := Coord{X: s.Center.X + s.Radius, Z: s.Center.Z}
+		return p, p
+	}
+	base := scaleVectorFixed(s.Facing, int64(s.Radius))
+	r := base.RotateFixed(-s.HalfAngle)
+	l := base.RotateFixed(s.HalfAngle)
+	return r.ToCoord(s.Center), l.ToCoord(s.Center)
+}
+
+// GetBoundaryCoords 采样扇形边界，返回按逆时针排列的闭合轮廓点集（首尾不重复），圆弧由 GetArcCoordsFixed 采样。
+func (s *SectorFixed) GetBoundaryCoords() []Coord {
+	right, _ := s.GetEdgeCoords()
+	if s.isFull() {
+		arc := GetArcCoordsFixed(right, s.Center, -AngleFull)
+		return arc[:len(arc)-1]
+	}
+	arc := GetArcCoordsFixed(right, s.Center, -2*s.HalfAngle)
+	return append([]Coord{s.Center}, arc...)
+}
+
+// IntersectCircle 判断扇形与圆是否相交（含接触），判定方式同 Sector.IntersectCircle，距离以整数精确比较。
+func (s *SectorFixed) IntersectCircle(c Circle) bool {
+	if s.isCoordInWedge(c.Center) {
+		return isWithinDist(s.Center, c.Center, int64(s.Radius)+int64(c.Radius))
+	}
+	right, left := s.GetEdgeCoords()
+	for _, p := range [2]Coord{right, left} {
+		if isSegmentWithinDist(s.Center, p, c.Center, int64(c.Radius)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectSegment 判断扇形与线段是否相交（含接触）：端点在扇形内、与任一半径边相交，
+// 或线段落在圆内的部分位于张角内。两端点都在扇形外且不与半径边相交时，线段在圆内的部分
+// 不会跨越张角边界，只需检查圆心到线段的最近点：最近点为端点时该端点在圆内却不在扇形内，
+// 即整段位于张角外；否则最近点为垂足，其方向与线段垂直，无需求出垂足坐标。
+func (s *SectorFixed) IntersectSegment(seg Segment) bool {
+	if s.IsCoordInside(seg.A) || s.IsCoordInside(seg.B) {
+		return true
+	}
+	if !s.isFull() {
+		right, left := s.GetEdgeCoords()
+		for _, p := range [2]Coord{right, left} {
+			if isSegmentCross(seg.A, seg.B, s.Center, p) {
+				return true
+			}
+		}
+	}
+	if !isSegmentWithinDist(seg.A, seg.B, s.Center, int64(s.Radius)) {
+		return false
+	}
+	if s.isFull() {
+		return true
+	}
+	if !isProjectionInside(seg.A, seg.B, s.Center) {
+		return false
+	}
+	ex, ez := int64(seg.B.X)-int64(seg.A.X), int64(seg.B.Z)-int64(seg.A.Z)
+	// 圆心在 A→B 左侧时垂足位于其右侧，方向为 A→B 的右法向
+	if Orient2D(seg.A, seg.B, s.Center) > 0 {
+		return s.isDirInWedge(ez, -ex)
+	}
+	return s.isDirInWedge(-ez, ex)
+}
+
+// IntersectConvex 判断扇形与凸多边形是否相交（含接触），顶点顺序不限，判定方式同 Sector.IntersectConvex。
+func (s *SectorFixed) IntersectConvex(vectors []Vector) bool {
+	if len(vectors) < 3 {
+		return false
+	}
+	if isCoordInConvexVectors(vectors, s.Center) {
+		return true
+	}
+	for i := range vectors {
+		seg := NewSegment(Coord(vectors[i]), Coord(vectors[(i+1)%len(vectors)]))
+		if s.IntersectSegment(seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFull 判断扇形是否退化为整圆。
+func (s *SectorFixed) isFull() bool {
+	return s.HalfAngle >= AngleHalf || (s.Facing.X == 0 && s.Facing.Z == 0)
+}
+
+// isCoordInWedge 判断点是否位于扇形张角所覆盖的无限锥形区域内，圆心本身视为在内。
+func (s *SectorFixed) isCoordInWedge(p Coord) bool {
+	if p == s.Center {
+		return true
+	}
+	return s.isDirInWedge(int64(p.X)-int64(s.Center.X), int64(p.Z)-int64(s.Center.Z))
+}
+
+// isDirInWedge 判断方向 (dx, dz) 是否位于两条边界方向之间，以整数叉积判定：
+// 张角小于 π 时须同时位于右边界的逆时针侧与左边界的顺时针侧，大于 π 时满足其一即可。
+func (s *SectorFixed) isDirInWedge(dx, dz int64) bool {
+	if s.isFull() || (dx == 0 && dz == 0) {
+		return true
+	}
+	base := scaleVectorFixed(s.Facing, sectorDirScale)
+	right, left := base.RotateFixed(-s.HalfAngle), base.RotateFixed(s.HalfAngle)
+	rx, rz, lx, lz := int64(right.X), int64(right.Z), int64(left.X), int64(left.Z)
+	cr := rx*dz - rz*dx // > 0 表示方向在右边界的逆时针侧
+	cl := dx*lz - dz*lx // > 0 表示方向在左边界的顺时针侧
+	switch w := rx*lz - rz*lx; {
+	case w > 0:
+		return cr >= 0 && cl >= 0
+	case w < 0:
+		return cr >= 0 || cl >= 0
+	case rx*lx+rz*lz > 0:
+		// 半角为 0：张角退化为朝向所在的射线
+		return cr == 0 && rx*dx+rz*dz > 0
+	}
+	// 张角恰为 π：右边界逆时针侧的半平面
+	return cr >= 0
+}
+
+// isWithinDist 判断两点距离是否不超过 r，以 128 位整数精确比较距离平方。
+func isWithinDist(a, b Coord, r int64) bool {
+	dx, dz := int64(b.X)-int64(a.X), int64(b.Z)-int64(a.Z)
+	return mul128(dx, dx).add(mul128(dz, dz)).sub(mul128(r, r)).sign() <= 0
+}
+
+// isSegmentWithinDist 判断点 p 到线段 ab 的最短距离是否不超过 r，全程为整数运算：
+// 垂足落在线段外时比较到两端点的距离，否则比较 (AB × AP)² 与 r²·|AB|²（以 math/big 计算）。
+func isSegmentWithinDist(a, b, p Coord, r int64) bool {
+	if a == b || !isProjectionInside(a, b, p) {
+		return isWithinDist(a, p, r) || isWithinDist(b, p, r)
+	}
+	ex, ez := int64(b.X)-int64(a.X), int64(b.Z)-int64(a.Z)
+	dx, dz := int64(p.X)-int64(a.X), int64(p.Z)-int64(a.Z)
+	c := mul128(ex, dz).sub(mul128(ez, dx)).big()
+	c.Mul(c, c)
+	limit := big.NewInt(r)
+	limit.Mul(limit, limit).Mul(limit, mul128(ex, ex).add(mul128(ez, ez)).big())
+	return c.Cmp(limit) <= 0
+}
+
+// isProjectionInside 判断点 p 在直线 ab 上的投影是否严格落在线段 ab 内部（不含端点）。
+func isProjectionInside(a, b, p Coord) bool {
+	ex, ez := int64(b.X)-int64(a.X), int64(b.Z)-int64(a.Z)
+	da := mul128(int64(p.X)-int64(a.X), ex).add(mul128(int64(p.Z)-int64(a.Z), ez))
+	db := mul128(int64(b.X)-int64(p.X), ex).add(mul128(int64(b.Z)-int64(p.Z), ez))
+	return da.sign() > 0 && db.sign() > 0
+}
@@ -1,5 +1,7 @@
 package geo
 
+import "math/big"
+
 // Segment 表示由两个端点 A、B 定义的有向线段。
 // 有向性体现在法向量方向（Pan 方法）及部分交点算法中，
 // 但点包含、距离等对称计算与方向无关。
@@ -69,27 +71,28 @@ func IsRectCross(p0, p1, q0, q1 Coord) bool {
 // IsLineSegmentCross 跨立实验：通过叉积判断两线段是否真正相交。
 // 算法原理：若 p0p1 跨立 q0q1（q0、q1 分别在 p0p1 两侧），
 // 且 q0q1 跨立 p0p1（p0、p1 分别在 q0q1 两侧），则两线段相交。
-// 叉积为 0 表示端点共线（恰好落在另一线段上），视为相交。
+// 叉积为 0 表示端点共线（落在另一线段所在直线上），视为相交；调用方需先以 IsRectCross 做排斥实验。
+// 方向由 Orient2D 精确判定，大坐标下不会因叉积溢出而误判。
 func IsLineSegmentCross(p0, p1, q0, q1 Coord) bool {
-	// q0q1 相对于 q0 的叉积值：判断 p0、p1 是否在 q0q1 两侧
-	b1 := cross(q1, p0, q0)
-	b2 := cross(q1, p1, q0)
+	// 判断 p0、p1 是否在 q0q1 两侧
+	b1 := Orient2D(q0, q1, p0)
+	b2 := Orient2D(q0, q1, p1)
 
-	// 叉积为 0 表示端点在另一线段所在直线上，视为相交
+	// 方向为 0 表示端点在另一线段所在直线上，视为相交
 	if b1 == 0 || b2 == 0 {
 		return true
 	}
 
-	// p0p1 相对于 p0 的叉积值：判断 q0、q1 是否在 p0p1 两侧
-	a1 := cross(p1, q0, p0)
-	a2 := cross(p1, q1, p0)
+	// 判断 q0、q1 是否在 p0p1 两侧
+	a1 := Orient2D(p0, p1, q0)
+	a2 := Orient2D(p0, p1, q1)
 
 	if a1 == 0 || a2 == 0 {
 		return true
 	}
 
-	// 两侧的叉积符号相反，说明两端点分居直线两侧（真正跨立）
-	return ((b1 < 0) != (b2 < 0)) && ((a1 < 0) != (a2 < 0))
+	// 两侧的方向相反，说明两端点分居直线两侧（真正跨立）
+	return b1 != b2 && a1 != a2
 }
 
 // DistanceToPoint 计算给定点到线段的最短距离，是 CalCoordDst 的别名方法。
@@ -100,7 +103,7 @@ func (s *Segment) DistanceToPoint(p Coord) float64 {
 // ClosestPoint 计算线段上距离给定点最近的点（投影点）。
 // 算法：将向量 AP 投影到 AB 上，得到参数 t = (AP·AB) / |AB|²，
 // t < 0 时最近点为端点 A，t > 1 时为端点 B，否则为线段内的投影点。
-// 使用 int32 截断投影点坐标，精度损失在游戏场景中可接受。
+// 投影点相对 A 的偏移量按包级取整方式 Rounding 取整，精度损失在游戏场景中可接受。
 func (s *Segment) ClosestPoint(p Coord) Coord {
 	ab := NewVector(s.A, s.B)
 	ap := NewVector(s.A, p)
@@ -123,35 +126,42 @@ func (s *Segment) ClosestPoint(p Coord) Coord {
 	}
 
 	return Coord{
-		X: s.A.X + int32(float64(ab.X)*t),
-		Z: s.A.Z + int32(float64(ab.Z)*t),
+		X: addInt32(s.A.X, roundInt32(float64(ab.X)*t)),
+		Z: addInt32(s.A.Z, roundInt32(float64(ab.Z)*t)),
 	}
 }
 
 // GetCrossCoord 计算两线段 P0P1 与 Q0Q1 的交点坐标。
 // 采用参数化方程法：先判断共线（叉积为 0 则无唯一交点），
-// 再依次通过排斥实验和跨立实验确认相交，最后用参数 t 求交点精确坐标。
+// 再依次通过排斥实验和跨立实验确认相交，最后用参数 t 求交点坐标。
+// 参数 t = [(Q0-P0) × S2] / (S1 × S2)（S1=P1-P0，S2=Q1-Q0）以整数精确表示，
+// 交点为 P0 + t·S1，偏移量按包级取整方式 Rounding 精确取整（默认向零截断，即向 P0 方向）；小坐标范围内以 int64 计算，否则由 lineCrossParams 以 math/big 求值。
+// 需要有理数精确交点时使用 SegmentIntersection。
 // 参考算法：https://stackoverflow.com/questions/563198
 func GetCrossCoord(p0, p1, q0, q1 Coord) (Coord, bool) {
-	v1 := NewVector(p0, p1)
-	v2 := NewVector(q0, q1)
-	// 两线段方向平行（叉积为 0）时无唯一交点
-	if v1.Cross(&v2) == 0 {
+	if !IsRectCross(p0, p1, q0, q1) || !IsLineSegmentCross(p0, p1, q0, q1) {
 		return Coord{}, false
 	}
-
-	if IsRectCross(p0, p1, q0, q1) {
-		if IsLineSegmentCross(p0, p1, q0, q1) {
-			// 参数化求交点：t = [(Q→P0) × S2] / (S1 × S2)，其中 S1=P1-P0, S2=Q1-Q0
-			s1X := float64(p1.X - p0.X)
-			s1Z := float64(p1.Z - p0.Z)
-			s2X := float64(q1.X - q0.X)
-			s2Z := float64(q1.Z - q0.Z)
-
-			t := (s2X*float64(p0.Z-q0.Z) - s2Z*float64(p0.X-q0.X)) / (-s2X*s1Z + s1X*s2Z)
-
-			return Coord{X: p0.X + int32(t*s1X), Z: p0.Z + int32(t*s1Z)}, true
+	s1X, s1Z := int64(p1.X)-int64(p0.X), int64(p1.Z)-int64(p0.Z)
+	spanX := int64(max(p0.X, p1.X, q0.X, q1.X)) - int64(min(p0.X, p1.X, q0.X, q1.X))
+	spanZ := int64(max(p0.Z, p1.Z, q0.Z, q1.Z)) - int64(min(p0.Z, p1.Z, q0.Z, q1.Z))
+	if max(spanX, spanZ) < 1<<20 {
+		// 坐标差值不超过 2^20 时，num·S1 不超过 2^62，可直接以 int64 精确计算
+		s2X, s2Z := int64(q1.X)-int64(q0.X), int64(q1.Z)-int64(q0.Z)
+		den := s1X*s2Z - s1Z*s2X
+		// 两线段方向平行（叉积为 0）时无唯一交点
+		if den == 0 {
+			return Coord{}, false
 		}
+		num := (int64(q0.X)-int64(p0.X))*s2Z - (int64(q0.Z)-int64(p0.Z))*s2X
+		return Coord{X: addInt32(p0.X, roundQuo(num*s1X, den)), Z: addInt32(p0.Z, roundQuo(num*s1Z, den))}, true
+	}
+	num, den := lineCrossParams(p0, p1, q0, q1)
+	if den.Sign() == 0 {
+		return Coord{}, false
+	}
+	axis := func(s int64) int32 {
+		return roundQuoBig(new(big.Int).Mul(big.NewInt(s), num), den)
 	}
-	return Coord{}, false
+	return Coord{X: addInt32(p0.X, axis(s1X)), Z: addInt32(p0.Z, axis(s1Z))}, true
 }
@@ -0,0 +1,358 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrSelfIntersection 表示多边形的边发生自相交（或相邻边共线回折）
+var ErrSelfIntersection = errors.New("geo: polygon is self-intersecting")
+
+// SimplePolygon 表示简单多边形（可为凹多边形），由一个外环和若干洞环组成，
+// 用于表示安全区、领地边界等无法用 Triangle 或 Convex 描述的区域。
+//
+// 约定外环逆时针、洞环顺时针排列，可通过 Normalize 统一方向。
+// 作为 Polygon 使用时，GetVertices、GetVectors、GetEdgeIDs、GetEdgeMidCoords 仅描述外环，
+// 第 i 条边连接 Outer[i] 与 Outer[(i+1)%n]；IsCoordInside、Area 等几何计算则会扣除洞。
+// 与其它多边形一同交给 PathFinder 时应设置 VertexIDs 与 EdgeIDs，
+// 否则顶点序号只在单个多边形内有效，不同多边形的顶点会被误认为同一顶点。
+type SimplePolygon struct {
+	Index     int32     // 多边形序号，全局唯一标识
+	Outer     []Coord   // 外环顶点
+	Holes     [][]Coord // 洞环顶点
+	VertexIDs []int32   // 外环各顶点的全局唯一序号（可为空），与 Outer 一一对应
+	EdgeIDs   []int32   // 外环各边的唯一序号（可为空），第 i 条边连接 Outer[i] 与 Outer[(i+1)%n]
+}
+
+// NewSimplePolygon 以外环与洞环创建简单多边形，顶点方向不作调整。
+func NewSimplePolygon(index int32, outer []Coord, holes ...[]Coord) *SimplePolygon {
+	return &SimplePolygon{
+		Index: index,
+		Outer: outer,
+		Holes: holes,
+	}
+}
+
+// IsCoordInside 判断点是否在多边形内（含外环与洞环的边界），与各环的方向无关。
+// 点落在任一环的边上时直接视为在内部（整数叉积精确判定）；
+// 否则以环绕数（winding number）判断：在外环内且不在任何洞内即为内部。
+func (s *SimplePolygon) IsCoordInside(p Coord) bool {
+	inside, onEdge := windingNumber(s.Outer, p)
+	if onEdge {
+		return true
+	}
+	if !inside {
+		return false
+	}
+	for _, hole := range s.Holes {
+		inside, onEdge = windingNumber(hole, p)
+		if onEdge {
+			return true
+		}
+		if inside {
+			return false
+		}
+	}
+	return true
+}
+
+// windingNumber 计算环绕数判断点是否在环内，并返回点是否恰好落在环的边上。
+// 采用 Dan Sunday 的算法：向上穿过的边在点左侧时加一，向下穿过的边在点右侧时减一，
+// 环绕数不为 0 即在环内。全部比较基于整数叉积，不受浮点误差影响。
+func windingNumber(ring []Coord, p Coord) (inside, onEdge bool) {
+	n := len(ring)
+	if n < 3 {
+		return false, false
+	}
+	wn := 0
+	for i := range n {
+		a, b := ring[i], ring[(i+1)%n]
+		side := orient(a, b, p)
+		if side == 0 && min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+			min(a.Z, b.Z) <= p.Z && p.Z <= max(a.Z, b.Z) {
+			return false, true
+		}
+		if a.Z <= p.Z {
+			if b.Z > p.Z && side > 0 {
+				wn++
+			}
+		} else if b.Z <= p.Z && side < 0 {
+			wn--
+		}
+	}
+	return wn != 0, false
+}
+
+// GetVectors 返回外环顶点的位置向量列表（Normalize 后为逆时针）。
+func (s *SimplePolygon) GetVectors() []Vector {
+	vectors := make([]Vector, len(s.Outer))
+	for i, c := range s.Outer {
+		vectors[i] = NewVectorByCoord(c)
+	}
+	return vectors
+}
+
+// ToRect 返回外环的轴对齐包围盒。
+func (s *SimplePolygon) ToRect() (minX, minZ, maxX, maxZ int32) {
+	if len(s.Outer) == 0 {
+		return
+	}
+	minX, minZ = int32(math.MaxInt32), int32(math.MaxInt32)
+	maxX, maxZ = int32(math.MinInt32), int32(math.MinInt32)
+	for _, c := range s.Outer {
+		minX, minZ = min(minX, c.X), min(minZ, c.Z)
+		maxX, maxZ = max(maxX, c.X), max(maxZ, c.Z)
+	}
+	return
+}
+
+// GetLocationToBorder 判断多边形包围盒与边界的象限重叠关系。
+func (s *SimplePolygon) GetLocationToBorder(b *Border) LocationState {
+	minX, minZ, maxX, maxZ := s.ToRect()
+	return b.RectLocation(minX, minZ, maxX, maxZ)
+}
+
+// GetIndex 返回多边形的全局唯一序号。
+func (s *SimplePolygon) GetIndex() int32 {
+	return s.Index
+}
+
+// GetEdgeIDs 返回外环各边的唯一序号列表。
+func (s *SimplePolygon) GetEdgeIDs() []int32 {
+	return s.EdgeIDs
+}
+
+// GetEdgeMidCoords 返回外环各边的中点坐标列表，顺序与顶点一致。
+func (s *SimplePolygon) GetEdgeMidCoords() []Coord {
+	n := len(s.Outer)
+	coords := make([]Coord, n)
+	for i, c := range s.Outer {
+		coords[i] = CalMidCoord(c, s.Outer[(i+1)%n])
+	}
+	return coords
+}
+
+// GetVertices 返回外环顶点列表，顶点序号取 VertexIDs；
+// 未设置 VertexIDs（或数量与外环不符）时取顶点在外环中的下标，此时序号不是全局唯一的。
+func (s *SimplePolygon) GetVertices() []Vertice {
+	vertices := make([]Vertice, len(s.Outer))
+	for i, c := range s.Outer {
+		index := int32(i)
+		if len(s.VertexIDs) == len(s.Outer) {
+			index = s.VertexIDs[i]
+		}
+		vertices[i] = Vertice{Index: index, Coord: c}
+	}
+	return vertices
+}
+
+// Area 返回多边形的面积，即外环面积减去各洞面积，与各环方向无关。
+func (s *SimplePolygon) Area() float64 {
+	area := math.Abs(signedArea2(s.Outer))
+	for _, hole := range s.Holes {
+		area -= math.Abs(signedArea2(hole))
+	}
+	return area / 2
+}
+
+// Perimeter 返回多边形的周长，包含外环与所有洞环的边长之和。
+func (s *SimplePolygon) Perimeter() float64 {
+	var perimeter float64
+	for _, ring := range s.rings() {
+		for i := range ring {
+			perimeter += CalDstCoordToCoord(ring[i], ring[(i+1)%len(ring)])
+		}
+	}
+	return perimeter
+}
+
+// Centroid 返回多边形的面积加权质心（扣除洞），与各环方向无关。
+// 各环按 Shoelace 公式累加一阶矩，外环取正、洞取负；面积为 0 时退化为外环顶点的算术平均值。
+func (s *SimplePolygon) Centroid() Coord {
+	var area, mx, mz float64
+	for k, ring := range s.rings() {
+		ringArea := signedArea2(ring)
+		// 外环按正面积、洞按负面积累加，统一方向后再求矩
+		sign := 1.0
+		if (ringArea < 0) == (k == 0) {
+			sign = -1
+		}
+		n := len(ring)
+		for i := range n {
+			a, b := ring[i], ring[(i+1)%n]
+			ax, az := float64(a.X), float64(a.Z)
+			bx, bz := float64(b.X), float64(b.Z)
+			c := (ax*bz - bx*az) * sign
+			area += c
+			mx += (ax + bx) * c
+			mz += (az + bz) * c
+		}
+	}
+	if area == 0 {
+		if len(s.Outer) == 0 {
+			return Coord{}
+		}
+		var sx, sz int64
+		for _, c := range s.Outer {
+			sx += int64(c.X)
+			sz += int64(c.Z)
+		}
+		n := int64(len(s.Outer))
+		return Coord{X: roundQuo(sx, n), Z: roundQuo(sz, n)}
+	}
+	return Coord{X: roundInt32(mx / (3 * area)), Z: roundInt32(mz / (3 * area))}
+}
+
+// Normalize 统一各环方向：外环逆时针、洞环顺时针，并移除连续重复点与闭合点。
+// 外环反转时同步重排 VertexIDs 与 EdgeIDs 保持与顶点的对应关系；外环移除了重复点时二者无法对应，将被清空。
+// 外环无效（不足 3 个顶点或面积为 0）时返回 ErrInvalidRing，无效的洞被丢弃。
+func (s *SimplePolygon) Normalize() error {
+	n := len(s.Outer)
+	outer := normalizeRing(s.Outer, true)
+	if outer == nil {
+		return ErrInvalidRing
+	}
+	if len(outer) != n {
+		s.VertexIDs, s.EdgeIDs = nil, nil
+	} else if signedArea2(s.Outer) < 0 {
+		if len(s.VertexIDs) == n {
+			s.VertexIDs = slices.Clone(s.VertexIDs)
+			slices.Reverse(s.VertexIDs)
+		}
+		if len(s.EdgeIDs) == n {
+			// 反转后第 i 条边连接原 Outer[n-1-i] 与 Outer[n-2-i]，即原第 (n-2-i) 条边
+			ids := make([]int32, n)
+			for i := range n {
+				ids[i] = s.EdgeIDs[(2*n-2-i)%n]
+			}
+			s.EdgeIDs = ids
+		}
+	}
+	s.Outer = outer
+	holes := s.Holes[:0]
+	for _, hole := range s.Holes {
+		if h := normalizeRing(hole, false); h != nil {
+			holes = append(holes, h)
+		}
+	}
+	s.Holes = holes
+	return nil
+}
+
+// Triangulate 使用耳切法将多边形剖分为三角形，各环方向不限，见 Triangulate 函数。
+func (s *SimplePolygon) Triangulate() ([]*Triangle, error) {
+	return Triangulate(s.Outer, s.Holes...)
+}
+
+// IsSelfIntersecting 判断多边形是否存在自相交，见 Validate。
+func (s *SimplePolygon) IsSelfIntersecting() bool {
+	_, _, ok := s.findIntersection()
+	return ok
+}
+
+// Validate 检查多边形是否为合法的简单多边形：
+// 各环至少 3 个顶点且面积不为 0；任意两条非相邻边（含不同环之间的边）不相交、不接触；
+// 相邻边不发生共线回折；每个洞都位于外环内且不位于其它洞内。
+func (s *SimplePolygon) Validate() error {
+	for k, ring := range s.rings() {
+		if len(ring) < 3 || isCollinearRing(ring) {
+			return fmt.Errorf("geo: ring %d is degenerate: %w", k, ErrInvalidRing)
+		}
+	}
+	// 先检查自相交再检查面积，使"8"字形等正负面积抵消的环报告为自相交而非退化
+	if e1, e2, ok := s.findIntersection(); ok {
+		return fmt.Errorf("%w: ring %d edge %d crosses ring %d edge %d",
+			ErrSelfIntersection, e1[0], e1[1], e2[0], e2[1])
+	}
+	for k, ring := range s.rings() {
+		if signedArea2(ring) == 0 {
+			return fmt.Errorf("geo: ring %d is degenerate: %w", k, ErrInvalidRing)
+		}
+	}
+	for k, hole := range s.Holes {
+		if !s.isCoordInRing(s.Outer, hole[0]) {
+			return fmt.Errorf("geo: hole %d: %w", k, ErrHoleOutside)
+		}
+		for j, other := range s.Holes {
+			if j != k && s.isCoordInRing(other, hole[0]) {
+				return fmt.Errorf("geo: hole %d lies inside hole %d: %w", k, j, ErrHoleOutside)
+			}
+		}
+	}
+	return nil
+}
+
+// findIntersection 两两检查所有边，返回第一对相交边的 [环下标, 边下标]。
+// 同一环内相邻的两条边共享一个端点，仅在共线回折（另一端点折回对方边上）时视为相交。
+func (s *SimplePolygon) findIntersection() ([2]int, [2]int, bool) {
+	type ringEdge struct {
+		ring, edge int
+		a, b       Coord
+	}
+	rings := s.rings()
+	var edges []ringEdge
+	for k, ring := range rings {
+		for i := range ring {
+			edges = append(edges, ringEdge{ring: k, edge: i, a: ring[i], b: ring[(i+1)%len(ring)]})
+		}
+	}
+	for i, e1 := range edges {
+		for _, e2 := range edges[i+1:] {
+			if !IsRectCross(e1.a, e1.b, e2.a, e2.b) {
+				continue
+			}
+			hit := false
+			n := len(rings[e1.ring])
+			switch {
+			case e1.ring == e2.ring && e2.edge == e1.edge+1:
+				hit = isFoldBack(e1.b, e1.a, e2.b)
+			case e1.ring == e2.ring && e1.edge == 0 && e2.edge == n-1:
+				hit = isFoldBack(e1.a, e1.b, e2.a)
+			default:
+				hit = isSegmentCross(e1.a, e1.b, e2.a, e2.b)
+			}
+			if hit {
+				return [2]int{e1.ring, e1.edge}, [2]int{e2.ring, e2.edge}, true
+			}
+		}
+	}
+	return [2]int{}, [2]int{}, false
+}
+
+// isCollinearRing 判断环的所有顶点是否共线（含全部重合）。
+func isCollinearRing(ring []Coord) bool {
+	for _, q := range ring {
+		if q == ring[0] {
+			continue
+		}
+		for _, p := range ring {
+			if orient(ring[0], q, p) != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// isFoldBack 判断共享端点 shared 的两条相邻边 shared→p 与 shared→q 是否共线且同向（即边发生回折重叠）。
+func isFoldBack(shared, p, q Coord) bool {
+	if orient(shared, p, q) != 0 {
+		return false
+	}
+	v1, v2 := NewVector(shared, p), NewVector(shared, q)
+	return v1.Dot(&v2) > 0
+}
+
+// isCoordInRing 判断点是否严格位于环内（边界上不算）。
+func (s *SimplePolygon) isCoordInRing(ring []Coord, p Coord) bool {
+	inside, onEdge := windingNumber(ring, p)
+	return inside && !onEdge
+}
+
+// rings 返回外环与全部洞环，外环下标为 0。
+func (s *SimplePolygon) rings() [][]Coord {
+	return slices.Concat([][]Coord{s.Outer}, s.Holes)
+}
@@ -0,0 +1,172 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// lShape 为顺时针的 L 形外环，面积 7500。
+var lShape = []Coord{{0, 0}, {0, 100}, {50, 100}, {50, 50}, {100, 50}, {100, 0}}
+
+func TestSimplePolygonIsCoordInside(t *testing.T) {
+	s := NewSimplePolygon(1, lShape, []Coord{{10, 10}, {20, 10}, {20, 20}, {10, 20}})
+	tests := []struct {
+		name string
+		p    Coord
+		want bool
+	}{
+		{"inside", Coord{5, 5}, true},
+		{"inside hole", Coord{15, 15}, false},
+		{"on hole edge", Coord{10, 15}, true},
+		{"in notch", Coord{75, 75}, false},
+		{"on notch edge", Coord{50, 75}, true},
+		{"on reflex corner edge", Coord{75, 50}, true},
+		{"on outer edge", Coord{100, 25}, true},
+		{"beyond outer edge", Coord{101, 25}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.IsCoordInside(tt.p); got != tt.want {
+				t.Fatalf("IsCoordInside(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplePolygonMeasure(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         *SimplePolygon
+		area      float64
+		perimeter float64
+		centroid  Coord
+	}{
+		{"square", NewSimplePolygon(0, []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}), 10000, 400, Coord{50, 50}},
+		{"clockwise square", NewSimplePolygon(0, []Coord{{0, 0}, {0, 100}, {100, 100}, {100, 0}}), 10000, 400, Coord{50, 50}},
+		{"L shape", NewSimplePolygon(0, lShape), 7500, 400, Coord{41, 41}}, // 41.67 按默认的 RoundTruncate 取整
+		{"square with hole", NewSimplePolygon(0, []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}, []Coord{{0, 0}, {50, 0}, {50, 50}, {0, 50}}), 7500, 600, Coord{58, 58}},
+		{"collinear", NewSimplePolygon(0, []Coord{{0, 0}, {10, 0}, {20, 0}}), 0, 40, Coord{10, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Area(); math.Abs(got-tt.area) > 1e-9 {
+				t.Fatalf("Area() = %v, want %v", got, tt.area)
+			}
+			if got := tt.s.Perimeter(); math.Abs(got-tt.perimeter) > 1e-9 {
+				t.Fatalf("Perimeter() = %v, want %v", got, tt.perimeter)
+			}
+			if got := tt.s.Centroid(); got != tt.centroid {
+				t.Fatalf("Centroid() = %v, want %v", got, tt.centroid)
+			}
+		})
+	}
+}
+
+func TestSimplePolygonValidate(t *testing.T) {
+	square := []Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	tests := []struct {
+		name string
+		s    *SimplePolygon
+		want error
+	}{
+		{"triangle", NewSimplePolygon(0, []Coord{{0, 0}, {10, 0}, {0, 10}}), nil},
+		{"L shape with hole", NewSimplePolygon(0, lShape, []Coord{{10, 10}, {20, 10}, {20, 20}, {10, 20}}), nil},
+		{"bowtie", NewSimplePolygon(0, []Coord{{0, 0}, {10, 10}, {10, 0}, {0, 10}}), ErrSelfIntersection},
+		{"fold back spike", NewSimplePolygon(0, []Coord{{0, 0}, {10, 0}, {10, 10}, {10, 5}}), ErrSelfIntersection},
+		{"hole crosses outer", NewSimplePolygon(0, square, []Coord{{5, 5}, {20, 5}, {20, 6}}), ErrSelfIntersection},
+		{"hole outside", NewSimplePolygon(0, square, []Coord{{20, 20}, {30, 20}, {30, 30}}), ErrHoleOutside},
+		{"hole inside hole", NewSimplePolygon(0, []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}},
+			[]Coord{{10, 10}, {90, 10}, {90, 90}, {10, 90}}, []Coord{{40, 40}, {60, 40}, {60, 60}}), ErrHoleOutside},
+		{"collinear", NewSimplePolygon(0, []Coord{{0, 0}, {10, 0}, {20, 0}}), ErrInvalidRing},
+		{"too few vertices", NewSimplePolygon(0, []Coord{{0, 0}, {10, 0}}), ErrInvalidRing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.s.Validate()
+			if !errors.Is(err, tt.want) || (err == nil) != (tt.want == nil) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.want)
+			}
+			// 退化环的边必然重叠，只对非退化的多边形校验 IsSelfIntersecting
+			if tt.want == ErrInvalidRing {
+				return
+			}
+			if got := tt.s.IsSelfIntersecting(); got != (tt.want == ErrSelfIntersection) {
+				t.Fatalf("IsSelfIntersecting() = %v", got)
+			}
+		})
+	}
+}
+
+func TestSimplePolygonNormalize(t *testing.T) {
+	s := NewSimplePolygon(1, lShape, []Coord{{10, 10}, {20, 10}, {20, 20}, {10, 20}})
+	s.VertexIDs = []int32{0, 1, 2, 3, 4, 5}
+	s.EdgeIDs = []int32{10, 11, 12, 13, 14, 15}
+	edges := make(map[[2]Coord]int32)
+	vertices := make(map[Coord]int32)
+	for i, c := range s.Outer {
+		edges[[2]Coord{c, s.Outer[(i+1)%len(s.Outer)]}] = s.EdgeIDs[i]
+		vertices[c] = s.VertexIDs[i]
+	}
+	if err := s.Normalize(); err != nil {
+		t.Fatalf("Normalize() = %v", err)
+	}
+	if signedArea2(s.Outer) <= 0 || signedArea2(s.Holes[0]) >= 0 {
+		t.Fatalf("Normalize() outer %v hole %v have wrong orientation", s.Outer, s.Holes[0])
+	}
+	// 反转后的每条边与原来的反向边序号相同，顶点序号随顶点移动
+	for i, v := range s.GetVertices() {
+		if v.Index != vertices[v.Coord] {
+			t.Fatalf("vertex %v index = %d, want %d", v.Coord, v.Index, vertices[v.Coord])
+		}
+		a, b := s.Outer[i], s.Outer[(i+1)%len(s.Outer)]
+		if s.EdgeIDs[i] != edges[[2]Coord{b, a}] {
+			t.Fatalf("edge %v-%v id = %d, want %d", a, b, s.EdgeIDs[i], edges[[2]Coord{b, a}])
+		}
+	}
+
+	// 移除重复点后序号无法对应，被清空
+	d := NewSimplePolygon(2, []Coord{{0, 0}, {10, 0}, {10, 0}, {10, 10}, {0, 0}})
+	d.VertexIDs, d.EdgeIDs = []int32{1, 2, 3, 4, 5}, []int32{1, 2, 3, 4, 5}
+	if err := d.Normalize(); err != nil || len(d.Outer) != 3 || d.VertexIDs != nil || d.EdgeIDs != nil {
+		t.Fatalf("Normalize() = %v, outer %v, ids %v %v", err, d.Outer, d.VertexIDs, d.EdgeIDs)
+	}
+	if err := NewSimplePolygon(3, []Coord{{0, 0}, {10, 0}, {20, 0}}).Normalize(); !errors.Is(err, ErrInvalidRing) {
+		t.Fatalf("Normalize() = %v, want %v", err, ErrInvalidRing)
+	}
+}
+
+func TestSimplePolygonLocator(t *testing.T) {
+	a := NewSimplePolygon(0, []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}})
+	b := NewSimplePolygon(1, lShape)
+	b.Outer = translateRing(b.Outer, 200, 0)
+	l := NewLocator([]Polygon{a, b})
+	tests := []struct {
+		name  string
+		p     Coord
+		want  Coord
+		owner Polygon
+	}{
+		{"left of a", Coord{-10, 50}, Coord{0, 50}, a},
+		{"inside a", Coord{50, 50}, Coord{50, 50}, a},
+		{"in notch of b", Coord{290, 70}, Coord{290, 50}, b},
+		{"right of b", Coord{320, 20}, Coord{300, 20}, b},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, owner, ok := l.Nearest(tt.p)
+			if !ok || p != tt.want || owner != tt.owner {
+				t.Fatalf("Nearest(%v) = %v, %v, %v, want %v, %v", tt.p, p, owner, ok, tt.want, tt.owner)
+			}
+		})
+	}
+}
+
+// translateRing 返回平移后的环。
+func translateRing(ring []Coord, dx, dz int32) []Coord {
+	out := make([]Coord, len(ring))
+	for i, c := range ring {
+		out[i] = Coord{c.X + dx, c.Z + dz}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+package geo
+
+import (
+	"math"
+	"slices"
+)
+
+// Transform 表示二维仿射变换矩阵，点 (x, z) 变换为 (A·x + B·z + Tx, C·x + D·z + Tz)：
+//
+//	| A  B  Tx |
+//	| C  D  Tz |
+//	| 0  0  1  |
+//
+// 平移、旋转、缩放等步骤先以 Compose 组合为一个矩阵，再一次性作用于图形；
+// 内部全程使用 float64，只在最后按包级取整方式 Rounding 取整一次，
+// 避免逐步调用 Vector.Rotate、Trunc、ToCoord 时每一步都截断而累积误差。
+type Transform struct {
+	A, B, Tx float64
+	C, D, Tz float64
+}
+
+// transformEpsilon 为判断变换是否保持直角、是否为等比缩放时使用的相对误差。
+const transformEpsilon = 1e-9
+
+// facingScale 为变换 OrientedRect 朝向向量时使用的长度，朝向只取方向，取较大的长度以减小取整误差。
+const facingScale = 1 << 16
+
+// NewIdentity 创建恒等变换。
+func NewIdentity() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// NewTranslate 创建平移变换。
+func NewTranslate(dx, dz float64) Transform {
+	return Transform{A: 1, D: 1, Tx: dx, Tz: dz}
+}
+
+// NewRotate 创建绕原点旋转 angle 弧度的变换，方向与 Vector.Rotate 一致。
+func NewRotate(angle float64) Transform {
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	return Transform{A: cos, B: -sin, C: sin, D: cos}
+}
+
+// NewRotateAround 创建绕指定中心点旋转 angle 弧度的变换。
+func NewRotateAround(center Coord, angle float64) Transform {
+	cx, cz := float64(center.X), float64(center.Z)
+	return NewTranslate(-cx, -cz).Compose(NewRotate(angle)).Compose(NewTranslate(cx, cz))
+}
+
+// NewScale 创建以原点为中心的缩放变换，sx、sz 为负时包含镜像。
+func NewScale(sx, sz float64) Transform {
+	return Transform{A: sx, D: sz}
+}
+
+// Compose 返回先执行 t、再执行 next 的组合变换（矩阵乘积 next·t）。
+func (t Transform) Compose(next Transform) Transform {
+	return Transform{
+		A:  next.A*t.A + next.B*t.C,
+		B:  next.A*t.B + next.B*t.D,
+		Tx: next.A*t.Tx + next.B*t.Tz + next.Tx,
+		C:  next.C*t.A + next.D*t.C,
+		D:  next.C*t.B + next.D*t.D,
+		Tz: next.C*t.Tx + next.D*t.Tz + next.Tz,
+	}
+}
+
+// Det 返回线性部分的行列式：绝对值为面积缩放比例，小于 0 表示变换包含镜像（逆时针顶点变为顺时针）。
+func (t Transform) Det() float64 {
+	return t.A*t.D - t.B*t.C
+}
+
+// Invert 返回逆变换；行列式为 0（退化为线或点）时不可逆，返回 false。
+func (t Transform) Invert() (Transform, bool) {
+	det := t.Det()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Transform{}, false
+	}
+	a, b, c, d := t.D/det, -t.B/det, -t.C/det, t.A/det
+	return Transform{
+		A: a, B: b, Tx: -(a*t.Tx + b*t.Tz),
+		C: c, D: d, Tz: -(c*t.Tx + d*t.Tz),
+	}, true
+}
+
+// ApplyCoord 对坐标点执行变换。
+func (t Transform) ApplyCoord(c Coord) Coord {
+	x, z := t.apply(float64(c.X), float64(c.Z))
+	return Coord{X: roundInt32(x), Z: roundInt32(z)}
+}
+
+// ApplyVector 对位移向量执行变换，只作用线性部分（向量不受平移影响）。
+func (t Transform) ApplyVector(v Vector) Vector {
+	x, z := float64(v.X), float64(v.Z)
+	return Vector{X: roundInt32(t.A*x + t.B*z), Z: roundInt32(t.C*x + t.D*z)}
+}
+
+// ApplySegment 对线段的两个端点执行变换。
+func (t Transform) ApplySegment(s Segment) Segment {
+	return NewSegment(t.ApplyCoord(s.A), t.ApplyCoord(s.B))
+}
+
+// ApplyCircle 对圆执行变换，半径按缩放比例 √|det| 取整。
+// 只有等比缩放（可含旋转与镜像）才能把圆变换为圆，否则结果为椭圆，返回 false。
+func (t Transform) ApplyCircle(c Circle) (Circle, bool) {
+	if !t.isSimilarity() {
+		return Circle{}, false
+	}
+	scale := math.Sqrt(math.Abs(t.Det()))
+	return NewCirCle(t.ApplyCoord(c.Center), roundInt32(float64(c.Radius)*scale)), true
+}
+
+// ApplyRectangle 对矩形执行变换，返回按逆时针排列的四个顶点位置向量（一般为平行四边形），
+// 可直接用于 Circle.IsInterPolygon 与 ClipConvex；变换包含镜像时顶点顺序随之调整，仍保持逆时针。
+func (t Transform) ApplyRectangle(r Rectangle) []Vector {
+	corners := r.GetVerticeCoords()
+	vectors := make([]Vector, len(corners))
+	for i, c := range corners {
+		vectors[i] = NewVectorByCoord(t.ApplyCoord(c))
+	}
+	if t.Det() < 0 {
+		slices.Reverse(vectors)
+	}
+	return vectors
+}
+
+// ApplyRectangleOBB 对矩形执行变换并以有向包围盒表示结果。
+// 只有保持直角的变换（旋转、平移、镜像及沿矩形轴向的缩放，两轴缩放比例可以不同）才能得到矩形，
+// 含错切时结果为平行四边形，返回 false，此时应改用 ApplyRectangle。
+// 朝向取矩形 X 轴（宽度方向）的像，半长、半宽分别为宽、高的一半乘以对应轴的缩放比例。
+func (t Transform) ApplyRectangleOBB(r Rectangle) (OrientedRect, bool) {
+	sx, sz := math.Hypot(t.A, t.C), math.Hypot(t.B, t.D)
+	if sx == 0 || sz == 0 || math.Abs(t.A*t.B+t.C*t.D) > transformEpsilon*sx*sz {
+		return OrientedRect{}, false
+	}
+	cx, cz := t.apply(float64(r.X)+float64(r.Width)/2, float64(r.Z)+float64(r.Height)/2)
+	return OrientedRect{
+		Center:     Coord{X: roundInt32(cx), Z: roundInt32(cz)},
+		HalfLength: roundInt32(float64(r.Width) / 2 * sx),
+		HalfWidth:  roundInt32(float64(r.Height) / 2 * sz),
+		Facing:     Vector{X: roundInt32(t.A / sx * facingScale), Z: roundInt32(t.C / sx * facingScale)},
+	}, true
+}
+
+// ApplyConvex 对凸多边形执行变换，返回新的凸多边形，原凸多边形不变。
+// 顶点、WtCoord 与 MergeTriangles 一同变换，顶点的 Index 保持不变；
+// 变换包含镜像（det < 0）时顶点反转为逆时针，EdgeIDs 随之重排，使第 k 条边仍连接第 k 与第 k+1 个顶点。
+func (t Transform) ApplyConvex(c *Convex) *Convex {
+	ret := &Convex{
+		Index:    c.Index,
+		Vertices: t.applyVertices(c.Vertices),
+		EdgeIDs:  t.applyEdgeIDs(c.EdgeIDs, len(c.Vertices)),
+		WtCoord:  t.ApplyCoord(c.WtCoord),
+	}
+	if c.MergeTriangles != nil {
+		ret.MergeTriangles = make([]*Triangle, len(c.MergeTriangles))
+		for i, tri := range c.MergeTriangles {
+			nt := &Triangle{
+				Index:    tri.Index,
+				Vertices: t.applyVertices(tri.Vertices),
+				EdgeIDs:  t.applyEdgeIDs(tri.EdgeIDs, len(tri.Vertices)),
+			}
+			nt.CalCenter()
+			ret.MergeTriangles[i] = nt
+		}
+	}
+	return ret
+}
+
+// apply 以浮点计算点 (x, z) 的变换结果。
+func (t Transform) apply(x, z float64) (float64, float64) {
+	return t.A*x + t.B*z + t.Tx, t.C*x + t.D*z + t.Tz
+}
+
+// isSimilarity 判断线性部分是否为等比缩放（可含旋转与镜像）：两列正交且长度相等。
+func (t Transform) isSimilarity() bool {
+	sx, sz := math.Hypot(t.A, t.C), math.Hypot(t.B, t.D)
+	tol := transformEpsilon * max(sx, sz)
+	return math.Abs(sx-sz) <= tol && math.Abs(t.A*t.B+t.C*t.D) <= tol*max(sx, sz)
+}
+
+// applyVertices 变换顶点列表，镜像时反转顺序以保持原有的环绕方向。
+func (t Transform) applyVertices(vertices []Vertice) []Vertice {
+	ret := make([]Vertice, len(vertices))
+	for i, v := range vertices {
+		ret[i] = Vertice{Index: v.Index, Coord: t.ApplyCoord(v.Coord)}
+	}
+	if t.Det() < 0 {
+		slices.Reverse(ret)
+	}
+	return ret
+}
+
+// applyEdgeIDs 按 applyVertices 的顶点顺序重排边序号。
+// 顶点反转后新的第 i 条边连接原顶点 n-1-i 与 n-2-i，即原来的第 n-2-i 条边。
+func (t Transform) applyEdgeIDs(edgeIDs []int32, n int) []int32 {
+	if edgeIDs == nil {
+		return nil
+	}
+	ret := make([]int32, len(edgeIDs))
+	if t.Det() >= 0 || len(edgeIDs) != n {
+		copy(ret, edgeIDs)
+		return ret
+	}
+	for i := range ret {
+		ret[i] = edgeIDs[(2*n-2-i)%n]
+	}
+	return ret
+}
@@ -0,0 +1,222 @@
+package geo
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestTransformApplyCoord(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   Transform
+		c    Coord
+		want Coord
+	}{
+		{"identity", NewIdentity(), Coord{-7, 3}, Coord{-7, 3}},
+		{"translate", NewTranslate(100, -5), Coord{1, 2}, Coord{101, -3}},
+		{"rotate quarter", NewRotate(math.Pi / 2), Coord{10, 0}, Coord{0, 10}},
+		{"rotate around", NewRotateAround(Coord{10, 10}, math.Pi), Coord{15, 10}, Coord{5, 10}},
+		{"mirror", NewScale(-1, 1), Coord{3, 4}, Coord{-3, 4}},
+		{"scale rotate translate", NewScale(2, 2).Compose(NewRotate(math.Pi / 2)).Compose(NewTranslate(100, 0)), Coord{10, 0}, Coord{100, 20}},
+		{"translate then scale", NewTranslate(1, 1).Compose(NewScale(3, 3)), Coord{0, 0}, Coord{3, 3}},
+		{"saturates", NewScale(1e10, 1), Coord{1, 0}, Coord{math.MaxInt32, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, RoundHalfEven, nil)
+			if got := tt.tr.ApplyCoord(tt.c); got != tt.want {
+				t.Fatalf("ApplyCoord(%v) = %v, want %v", tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformApplyVectorAndSegment(t *testing.T) {
+	setRounding(t, RoundHalfEven, nil)
+	tr := NewScale(2, 2).Compose(NewRotate(math.Pi / 2)).Compose(NewTranslate(100, 0))
+	if got := tr.ApplyVector(Vector{X: 10}); got != (Vector{X: 0, Z: 20}) {
+		t.Fatalf("ApplyVector() = %v, want %v (translation ignored)", got, Vector{X: 0, Z: 20})
+	}
+	if got, want := NewTranslate(1, 2).ApplySegment(NewSegment(Coord{}, Coord{1, 1})), NewSegment(Coord{1, 2}, Coord{2, 3}); got != want {
+		t.Fatalf("ApplySegment() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformInvert(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   Transform
+		ok   bool
+	}{
+		{"identity", NewIdentity(), true},
+		{"composed", NewScale(2, 2).Compose(NewRotate(math.Pi / 3)).Compose(NewTranslate(100, -40)), true},
+		{"mirror", NewScale(-1, 3), true},
+		{"shear", Transform{A: 1, B: 1, D: 1, Tx: 5}, true},
+		{"collapsed axis", NewScale(0, 1), false},
+		{"collapsed to line", Transform{A: 1, B: 2, C: 2, D: 4}, false},
+		{"NaN", NewScale(math.NaN(), 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, RoundHalfEven, nil)
+			inv, ok := tt.tr.Invert()
+			if ok != tt.ok {
+				t.Fatalf("Invert() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			id := tt.tr.Compose(inv)
+			for _, v := range []float64{id.A - 1, id.B, id.C, id.D - 1, id.Tx, id.Tz} {
+				if math.Abs(v) > 1e-9 {
+					t.Fatalf("Compose(Invert()) = %+v, want identity", id)
+				}
+			}
+		})
+	}
+}
+
+// TestTransformRoundTrip 校验组合变换只取整一次：放大后再逆变换回原点，整数坐标不产生误差。
+func TestTransformRoundTrip(t *testing.T) {
+	setRounding(t, RoundHalfEven, nil)
+	rng := rand.New(rand.NewPCG(51, 0))
+	for range 2000 {
+		s := 2 + rng.Float64()*3
+		tr := NewScale(s, s).Compose(NewRotate(rng.Float64() * 2 * math.Pi)).Compose(NewTranslate(float64(rng.Int32N(2000)-1000), float64(rng.Int32N(2000)-1000)))
+		inv, ok := tr.Invert()
+		if !ok {
+			t.Fatalf("Invert(%+v) = false", tr)
+		}
+		p := Coord{rng.Int32N(20000) - 10000, rng.Int32N(20000) - 10000}
+		// 取整误差至多 √2/2，缩放比例不小于 2 时经逆变换缩小到 1/2 以内，再次取整即回到原点
+		if q := inv.ApplyCoord(tr.ApplyCoord(p)); q != p {
+			t.Fatalf("%+v: round trip of %v = %v", tr, p, q)
+		}
+	}
+}
+
+func TestTransformApplyCircle(t *testing.T) {
+	circle := NewCirCle(Coord{}, 50)
+	tests := []struct {
+		name string
+		tr   Transform
+		want Circle
+		ok   bool
+	}{
+		{"similarity", NewScale(2, 2).Compose(NewRotate(math.Pi / 2)).Compose(NewTranslate(100, 0)), NewCirCle(Coord{100, 0}, 100), true},
+		{"mirror similarity", NewScale(-3, 3), NewCirCle(Coord{}, 150), true},
+		{"translate", NewTranslate(7, 8), NewCirCle(Coord{7, 8}, 50), true},
+		{"ellipse", NewScale(1, 2), Circle{}, false},
+		{"shear", Transform{A: 1, B: 1, D: 1}, Circle{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, RoundHalfEven, nil)
+			got, ok := tt.tr.ApplyCircle(circle)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("ApplyCircle() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTransformApplyRectangle(t *testing.T) {
+	rect := NewRectangle(0, 0, 100, 40)
+	tests := []struct {
+		name string
+		tr   Transform
+		want []Vector
+	}{
+		{"translate", NewTranslate(10, 10), []Vector{{10, 10}, {110, 10}, {110, 50}, {10, 50}}},
+		{"shear", Transform{A: 1, B: 1, D: 1}, []Vector{{0, 0}, {100, 0}, {140, 40}, {40, 40}}},
+		{"mirror keeps counter-clockwise", NewScale(-1, 1), []Vector{{0, 40}, {-100, 40}, {-100, 0}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tr.ApplyRectangle(rect)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ApplyRectangle() = %v, want %v", got, tt.want)
+			}
+			area := int64(0)
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ApplyRectangle() = %v, want %v", got, tt.want)
+				}
+				area += got[i].Cross(&got[(i+1)%len(got)])
+			}
+			if area <= 0 {
+				t.Fatalf("ApplyRectangle() = %v is not counter-clockwise", got)
+			}
+		})
+	}
+}
+
+func TestTransformApplyRectangleOBB(t *testing.T) {
+	rect := NewRectangle(0, 0, 100, 40)
+	tests := []struct {
+		name string
+		tr   Transform
+		want OrientedRect
+		ok   bool
+	}{
+		{"translate", NewTranslate(10, 0), OrientedRect{Center: Coord{60, 20}, HalfLength: 50, HalfWidth: 20, Facing: Vector{facingScale, 0}}, true},
+		{"rotate quarter", NewRotate(math.Pi / 2), OrientedRect{Center: Coord{-20, 50}, HalfLength: 50, HalfWidth: 20, Facing: Vector{0, facingScale}}, true},
+		{"axis scale", NewScale(2, 3), OrientedRect{Center: Coord{100, 60}, HalfLength: 100, HalfWidth: 60, Facing: Vector{facingScale, 0}}, true},
+		{"shear", Transform{A: 1, B: 1, D: 1}, OrientedRect{}, false},
+		{"collapsed", NewScale(0, 1), OrientedRect{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, RoundHalfEven, nil)
+			got, ok := tt.tr.ApplyRectangleOBB(rect)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("ApplyRectangleOBB() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTransformApplyConvex(t *testing.T) {
+	tri := &Triangle{Index: 3, Vertices: []Vertice{{Index: 0, Coord: Coord{0, 0}}, {Index: 1, Coord: Coord{10, 0}}, {Index: 2, Coord: Coord{0, 10}}}, EdgeIDs: []int32{100, 101, 102}}
+	cv := NewConvex(tri, 7)
+	// edge 返回第 k 条边两端顶点的序号，与方向无关
+	edge := func(c *Convex, k int) [2]int32 {
+		a, b := c.Vertices[k].Index, c.Vertices[(k+1)%len(c.Vertices)].Index
+		return [2]int32{min(a, b), max(a, b)}
+	}
+	tests := []struct {
+		name  string
+		tr    Transform
+		first Coord
+	}{
+		{"translate", NewTranslate(5, 5), Coord{5, 5}},
+		{"rotate", NewRotate(math.Pi / 2), Coord{0, 0}},
+		{"mirror", NewScale(-1, 1), Coord{0, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRounding(t, RoundHalfEven, nil)
+			got := tt.tr.ApplyConvex(cv)
+			if !got.CheckConvex() || got.Index != cv.Index || got.Vertices[0].Coord != tt.first {
+				t.Fatalf("ApplyConvex() = %v, want counter-clockwise convex starting at %v", got.Vertices, tt.first)
+			}
+			for k, id := range got.EdgeIDs {
+				if id != cv.EdgeIDs[0] && id != cv.EdgeIDs[1] && id != cv.EdgeIDs[2] {
+					t.Fatalf("ApplyConvex() EdgeIDs = %v", got.EdgeIDs)
+				}
+				for j := range cv.EdgeIDs {
+					if cv.EdgeIDs[j] == id && edge(cv, j) != edge(got, k) {
+						t.Fatalf("ApplyConvex() edge %d = %v, want %v", id, edge(got, k), edge(cv, j))
+					}
+				}
+			}
+			if len(got.MergeTriangles) != 1 || got.MergeTriangles[0].Vertices[0].Coord != tt.first {
+				t.Fatalf("ApplyConvex() MergeTriangles = %v", got.MergeTriangles)
+			}
+		})
+	}
+	if cv.Vertices[1].Coord != (Coord{10, 0}) || cv.EdgeIDs[0] != 100 {
+		t.Fatalf("ApplyConvex() modified the input to %v %v", cv.Vertices, cv.EdgeIDs)
+	}
+}
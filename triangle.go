@@ -11,7 +11,7 @@ import (
 type Triangle struct {
 	Index    int32     // 三角形序号，全局唯一标识
 	Vertices []Vertice // 三个顶点列表（顺序决定法向量方向）
-	EdgeIDs  []int32   // 三条边的唯一序号，启动时生成并缓存
+	EdgeIDs  []int32   // 三条边的唯一序号，启动时生成并缓存，第 k 条边连接 Vertices[k] 与 Vertices[(k+1)%3]
 	Center   Coord     // 三角形重心，由 CalCenter 预计算后缓存
 }
 
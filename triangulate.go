@@ -0,0 +1,262 @@
+package geo
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// dedupeRing 返回移除连续重复点及与起点重复的闭合点后的环，不修改原切片。
+func dedupeRing(ring []Coord) []Coord {
+	ret := make([]Coord, 0, len(ring))
+	for _, c := range ring {
+		if len(ret) > 0 && ret[len(ret)-1] == c {
+			continue
+		}
+		ret = append(ret, c)
+	}
+	for len(ret) > 1 && ret[0] == ret[len(ret)-1] {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
+// Triangulate 使用耳切法将简单多边形（可为凹多边形，可带洞）剖分为三角形，不做 Delaunay 优化。
+// 环的方向不限，连续重复点与闭合点会被移除；共线顶点会保留为三角形顶点。
+// 洞通过桥接边并入外环后再剖分（见 bridgeHoles）。
+//
+// 剖分前以 SimplePolygon.Validate 校验输入，自相交时返回包装了 ErrSelfIntersection 的错误并指明相交的边，
+// 环退化时返回包装了 ErrInvalidRing 的错误，洞不在外环内或相互嵌套时返回包装了 ErrHoleOutside 的错误。
+// 洞与外环或其它洞接触同样视为相交。
+//
+// 返回的三角形均为逆时针，坐标相同的顶点共享同一个 Vertice.Index，
+// EdgeIDs 按三角形及其边的遍历顺序生成（与 NavMesh 的约定一致），并已通过 CalCenter 预计算重心，
+// 可直接传给 NewConvex 或 MergeTrianglesToConvexes。需要质量更好的剖分时使用 NavMeshBuilder。
+func Triangulate(ring []Coord, holes ...[]Coord) ([]*Triangle, error) {
+	p := &SimplePolygon{Outer: dedupeRing(ring)}
+	for _, h := range holes {
+		p.Holes = append(p.Holes, dedupeRing(h))
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if err := p.Normalize(); err != nil {
+		return nil, err
+	}
+
+	coords, outer, holeRings := indexRings(p.Outer, p.Holes)
+	merged, err := bridgeHoles(coords, outer, holeRings)
+	if err != nil {
+		return nil, err
+	}
+	tris, err := earClip(coords, merged)
+	if err != nil {
+		return nil, err
+	}
+	return newNavMesh(coords, tris).Triangles, nil
+}
+
+// bridgeHoles 通过桥接边将所有洞合并进外边界，得到一个可供耳切法处理的单一环。
+// 采用 Eberly 的方法：按洞的最大 X 坐标降序依次处理，从洞的最右顶点 H 沿 +X 方向
+// 发射射线，找到外环上可见的顶点 M，并插入 M→H→(洞顶点)→H→M 的往返桥接序列。
+// 外环须为逆时针，洞须为顺时针，ring 与 holes 中的元素均为 coords 的下标。
+func bridgeHoles(coords []Coord, ring []int32, holes [][]int32) ([]int32, error) {
+	maxX := func(hole []int32) int32 {
+		x := int32(math.MinInt32)
+		for _, v := range hole {
+			x = max(x, coords[v].X)
+		}
+		return x
+	}
+	holes = slices.Clone(holes)
+	slices.SortStableFunc(holes, func(a, b []int32) int {
+		return cmp.Compare(maxX(b), maxX(a))
+	})
+
+	for _, hole := range holes {
+		var err error
+		ring, err = bridgeHole(coords, ring, hole)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ring, nil
+}
+
+// bridgeHole 将单个洞桥接到当前环上，返回合并后的新环。
+func bridgeHole(coords []Coord, ring []int32, hole []int32) ([]int32, error) {
+	hi := 0
+	for i := range hole {
+		if coords[hole[i]].X > coords[hole[hi]].X {
+			hi = i
+		}
+	}
+	h := coords[hole[hi]]
+
+	// 从 H 沿 +X 方向发射射线，寻找最近的相交边。
+	// 仅考虑自下而上的边：逆时针外环与顺时针洞的此类边内侧均朝向 -X，正对 H。
+	n := len(ring)
+	bestX := math.Inf(1)
+	best := -1
+	for i := range n {
+		a := coords[ring[i]]
+		b := coords[ring[(i+1)%n]]
+		if a.Z > h.Z || b.Z < h.Z || a.Z == b.Z {
+			continue
+		}
+		x := float64(a.X) + float64(h.Z-a.Z)*float64(b.X-a.X)/float64(b.Z-a.Z)
+		if x < float64(h.X) || x >= bestX {
+			continue
+		}
+		bestX = x
+		best = i
+	}
+	if best < 0 {
+		return nil, ErrHoleOutside
+	}
+
+	a := coords[ring[best]]
+	b := coords[ring[(best+1)%n]]
+	var m int
+	switch {
+	case a.Z == h.Z && float64(a.X) == bestX:
+		m = best
+	case b.Z == h.Z && float64(b.X) == bestX:
+		m = (best + 1) % n
+	default:
+		// 射线交于边内部：先取该边 X 较大的端点 P 作为候选，
+		// 再检查三角形 H-I-P 内的顶点，取与射线夹角最小者（其必然对 H 可见）
+		m = best
+		if b.X > a.X {
+			m = (best + 1) % n
+		}
+		p := coords[ring[m]]
+		hx, hz := float64(h.X), float64(h.Z)
+		px, pz := float64(p.X), float64(p.Z)
+		for j := range n {
+			v := coords[ring[j]]
+			if j == m || v.X <= h.X || v == p {
+				continue
+			}
+			vx, vz := float64(v.X), float64(v.Z)
+			if !isCoordInTriangleF(hx, hz, bestX, hz, px, pz, vx, vz) {
+				continue
+			}
+			cur := coords[ring[m]]
+			// 比较 |dz|/dx 的大小（交叉相乘避免除法），相同时取更近者
+			lhs := math.Abs(vz-hz) * float64(cur.X-h.X)
+			rhs := math.Abs(float64(cur.Z)-hz) * (vx - hx)
+			if lhs < rhs || lhs == rhs && v.X < cur.X {
+				m = j
+			}
+		}
+	}
+
+	// 同一顶点可能因先前的桥接在环中出现多次，选取 H 位于其局部内角中的那一次
+	for j := range n {
+		if ring[j] == ring[m] && isLocallyInside(coords, ring, j, h) {
+			m = j
+			break
+		}
+	}
+
+	merged := make([]int32, 0, n+len(hole)+2)
+	merged = append(merged, ring[:m+1]...)
+	merged = append(merged, hole[hi:]...)
+	merged = append(merged, hole[:hi]...)
+	merged = append(merged, hole[hi], ring[m])
+	merged = append(merged, ring[m+1:]...)
+	return merged, nil
+}
+
+// isLocallyInside 判断从环上第 i 个顶点指向 p 的方向是否落在该顶点的内角范围内。
+// 凸顶点的内角为两条邻边左侧半平面的交集，凹顶点则为并集。
+func isLocallyInside(coords []Coord, ring []int32, i int, p Coord) bool {
+	n := len(ring)
+	prev := coords[ring[(i-1+n)%n]]
+	cur := coords[ring[i]]
+	next := coords[ring[(i+1)%n]]
+	left1 := cross(next, p, cur) > 0
+	left2 := cross(cur, p, prev) > 0
+	if cross(cur, next, prev) >= 0 {
+		return left1 && left2
+	}
+	return left1 || left2
+}
+
+// isCoordInTriangleF 以浮点叉积判断点 p 是否在三角形 abc 内（含边界），与三角形方向无关。
+func isCoordInTriangleF(ax, az, bx, bz, cx, cz, px, pz float64) bool {
+	d1 := (bx-ax)*(pz-az) - (bz-az)*(px-ax)
+	d2 := (cx-bx)*(pz-bz) - (cz-bz)*(px-bx)
+	d3 := (ax-cx)*(pz-cz) - (az-cz)*(px-cx)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// isCoordInTriangle 判断点 p 是否在逆时针三角形 abc 内（含边界）。
+func isCoordInTriangle(a, b, c, p Coord) bool {
+	return cross(b, p, a) >= 0 && cross(c, p, b) >= 0 && cross(a, p, c) >= 0
+}
+
+// earClip 使用耳切法（Ear Clipping）将逆时针简单环剖分为三角形。
+// 每轮在剩余顶点中寻找"耳朵"：严格凸的顶点，且其与前后邻点构成的三角形内
+// （含边界）不包含环上其它顶点，找到后输出该三角形并移除耳尖。
+// 与耳朵三个顶点坐标重合的点（桥接产生的重复顶点）不参与包含判断。
+// 共线顶点不会被当作耳尖，而是在相邻顶点被切除后自然成为凸顶点。
+func earClip(coords []Coord, ring []int32) ([][3]int32, error) {
+	n := len(ring)
+	if n < 3 {
+		return nil, ErrTriangulate
+	}
+	prev := make([]int, n)
+	next := make([]int, n)
+	for i := range n {
+		prev[i] = (i - 1 + n) % n
+		next[i] = (i + 1) % n
+	}
+
+	isEar := func(i int) bool {
+		a := coords[ring[prev[i]]]
+		b := coords[ring[i]]
+		c := coords[ring[next[i]]]
+		if cross(b, c, a) <= 0 {
+			return false
+		}
+		for j := next[next[i]]; j != prev[i]; j = next[j] {
+			p := coords[ring[j]]
+			if p == a || p == b || p == c {
+				continue
+			}
+			if isCoordInTriangle(a, b, c, p) {
+				return false
+			}
+		}
+		return true
+	}
+
+	tris := make([][3]int32, 0, n-2)
+	cur := 0
+	for remain := n; remain > 3; remain-- {
+		found := false
+		for range remain {
+			if isEar(cur) {
+				found = true
+				break
+			}
+			cur = next[cur]
+		}
+		if !found {
+			return nil, ErrTriangulate
+		}
+		tris = append(tris, [3]int32{ring[prev[cur]], ring[cur], ring[next[cur]]})
+		next[prev[cur]] = next[cur]
+		prev[next[cur]] = prev[cur]
+		cur = next[cur]
+	}
+	a, b, c := coords[ring[prev[cur]]], coords[ring[cur]], coords[ring[next[cur]]]
+	if cross(b, c, a) > 0 {
+		tris = append(tris, [3]int32{ring[prev[cur]], ring[cur], ring[next[cur]]})
+	}
+	return tris, nil
+}
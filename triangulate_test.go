@@ -0,0 +1,168 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestTriangulate(t *testing.T) {
+	square := []Coord{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	tests := []struct {
+		name   string
+		ring   []Coord
+		holes  [][]Coord
+		area2  float64
+		inside []Coord // 必须被覆盖的点
+		holeAt []Coord // 不能被覆盖的点
+	}{
+		{"triangle", []Coord{{0, 0}, {10, 0}, {0, 10}}, nil, 100, []Coord{{1, 1}}, nil},
+		{"square", square, nil, 20000, []Coord{{50, 50}, {99, 1}}, nil},
+		{"clockwise L with collinear points and closing point", []Coord{{0, 0}, {0, 20}, {0, 40}, {20, 40}, {20, 20}, {40, 20}, {40, 0}, {20, 0}, {0, 0}},
+			nil, 2400, []Coord{{10, 30}, {30, 10}}, []Coord{{30, 30}}},
+		{"square with hole", square, [][]Coord{{{40, 40}, {60, 40}, {60, 60}, {40, 60}}}, 19200, []Coord{{20, 50}, {80, 50}}, []Coord{{50, 50}}},
+		{"two holes", square, [][]Coord{{{10, 10}, {30, 10}, {30, 30}, {10, 30}}, {{70, 70}, {70, 90}, {90, 90}, {90, 70}}}, 18400,
+			[]Coord{{50, 50}, {5, 95}}, []Coord{{20, 20}, {80, 80}}},
+		{"star", starRing(7, 500, 200), nil, 2 * NewSimplePolygon(0, starRing(7, 500, 200)).Area(), []Coord{{0, 0}}, []Coord{{0, 450}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := Triangulate(tt.ring, tt.holes...)
+			if err != nil {
+				t.Fatalf("Triangulate() = %v", err)
+			}
+			s := NewSimplePolygon(0, dedupeRing(tt.ring), tt.holes...)
+			checkTriangulation(t, ts, s)
+			if got := triangulationArea2(ts); math.Abs(got-tt.area2) > 1e-6 {
+				t.Fatalf("area2 = %v, want %v", got, tt.area2)
+			}
+			for _, p := range tt.inside {
+				if !trianglesCover(ts, p) {
+					t.Fatalf("%v is not covered", p)
+				}
+			}
+			for _, p := range tt.holeAt {
+				if trianglesCover(ts, p) {
+					t.Fatalf("%v is covered", p)
+				}
+			}
+		})
+	}
+}
+
+func TestTriangulateInvalid(t *testing.T) {
+	square := []Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	tests := []struct {
+		name  string
+		ring  []Coord
+		holes [][]Coord
+		want  error
+	}{
+		{"bowtie", []Coord{{0, 0}, {10, 10}, {10, 0}, {0, 10}}, nil, ErrSelfIntersection},
+		{"hole touching outer", square, [][]Coord{{{0, 5}, {5, 3}, {5, 7}}}, ErrSelfIntersection},
+		{"hole outside", square, [][]Coord{{{20, 20}, {30, 20}, {30, 30}}}, ErrHoleOutside},
+		{"collinear", []Coord{{0, 0}, {10, 0}, {20, 0}}, nil, ErrInvalidRing},
+		{"too few vertices", []Coord{{0, 0}, {10, 0}, {10, 0}}, nil, ErrInvalidRing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Triangulate(tt.ring, tt.holes...); !errors.Is(err, tt.want) {
+				t.Fatalf("Triangulate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestTriangulateRandom 校验随机星形多边形（可带一个洞）的剖分覆盖范围与面积正确。
+func TestTriangulateRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(19, 0))
+	for range 200 {
+		n := 3 + rng.IntN(30)
+		ring := make([]Coord, n)
+		for i := range ring {
+			a := (float64(i) + rng.Float64()*0.8) * 2 * math.Pi / float64(n)
+			r := 300 + rng.Float64()*700
+			ring[i] = Coord{int32(math.Round(r * math.Cos(a))), int32(math.Round(r * math.Sin(a)))}
+		}
+		var holes [][]Coord
+		if rng.IntN(2) == 0 {
+			holes = append(holes, []Coord{{-50, -50}, {-50, 50}, {50, 50}, {50, -50}})
+		}
+		s := NewSimplePolygon(0, ring, holes...)
+		if s.Validate() != nil {
+			continue
+		}
+		ts, err := s.Triangulate()
+		if err != nil {
+			t.Fatalf("Triangulate(%v, %v) = %v", ring, holes, err)
+		}
+		checkTriangulation(t, ts, s)
+		if got := triangulationArea2(ts); math.Abs(got-2*s.Area()) > 1e-6 {
+			t.Fatalf("Triangulate(%v, %v) area2 = %v, want %v", ring, holes, got, 2*s.Area())
+		}
+		for range 50 {
+			p := Coord{rng.Int32N(2001) - 1000, rng.Int32N(2001) - 1000}
+			if trianglesCover(ts, p) != s.IsCoordInside(p) {
+				t.Fatalf("Triangulate(%v, %v) covers %v = %v", ring, holes, p, !s.IsCoordInside(p))
+			}
+		}
+	}
+}
+
+// checkTriangulation 校验剖分结果：三角形数量为 n+2h-2，均为逆时针，
+// 坐标相同的顶点共享序号，内部边恰好被两个三角形共享、边界边只属于一个三角形。
+func checkTriangulation(t *testing.T, ts []*Triangle, s *SimplePolygon) {
+	t.Helper()
+	vertices := len(s.Outer)
+	for _, h := range s.Holes {
+		vertices += len(h)
+	}
+	if want := vertices + 2*len(s.Holes) - 2; len(ts) != want {
+		t.Fatalf("len(triangles) = %d, want %d", len(ts), want)
+	}
+	indexes := make(map[Coord]int32)
+	edges := make(map[int32]int)
+	for _, tr := range ts {
+		c := []Coord{tr.Vertices[0].Coord, tr.Vertices[1].Coord, tr.Vertices[2].Coord}
+		if signedArea2(c) <= 0 {
+			t.Fatalf("triangle %v is not counter-clockwise", c)
+		}
+		for _, v := range tr.Vertices {
+			if i, ok := indexes[v.Coord]; ok && i != v.Index {
+				t.Fatalf("vertex %v has indexes %d and %d", v.Coord, i, v.Index)
+			}
+			indexes[v.Coord] = v.Index
+		}
+		for _, id := range tr.EdgeIDs {
+			edges[id]++
+		}
+	}
+	if inner := (3*len(ts) - vertices) / 2; len(edges) != inner+vertices {
+		t.Fatalf("len(edges) = %d, want %d", len(edges), inner+vertices)
+	}
+	for id, n := range edges {
+		if n > 2 {
+			t.Fatalf("edge %d is shared by %d triangles", id, n)
+		}
+	}
+}
+
+// triangulationArea2 返回全部三角形的两倍面积之和。
+func triangulationArea2(ts []*Triangle) float64 {
+	var area2 float64
+	for _, tr := range ts {
+		area2 += signedArea2([]Coord{tr.Vertices[0].Coord, tr.Vertices[1].Coord, tr.Vertices[2].Coord})
+	}
+	return area2
+}
+
+// trianglesCover 判断点是否被任一三角形覆盖（含边界）。
+func trianglesCover(ts []*Triangle, p Coord) bool {
+	for _, tr := range ts {
+		if tr.IsCoordInside(p) {
+			return true
+		}
+	}
+	return false
+}
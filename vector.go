@@ -72,11 +72,11 @@ func (v *Vector) Length() float64 {
 
 // Trunc 按比例缩放向量，返回新向量。
 // ratio > 1 放大，ratio < 1 缩小，ratio < 0 反向。
-// 使用 int32 截断会引入舍入误差，精度要求高时应注意累积误差。
+// 结果分量按包级取整方式 Rounding 取整（默认向零截断），精度要求高时应注意累积误差。
 func (v *Vector) Trunc(ratio float64) Vector {
 	return Vector{
-		X: int32(ratio * float64(v.X)),
-		Z: int32(ratio * float64(v.Z)),
+		X: roundInt32(ratio * float64(v.X)),
+		Z: roundInt32(ratio * float64(v.Z)),
 	}
 }
 
@@ -105,7 +105,7 @@ func (v Vector) ToCoord(start Coord) Coord {
 //	x' = x·cosθ - z·sinθ
 //	z' = x·sinθ + z·cosθ
 //
-// 结果分量以 int32 截断，存在浮点→整数的舍入误差，大角度连续旋转时误差会累积。
+// 结果分量按包级取整方式 Rounding 取整（默认向零截断），大角度连续旋转时误差会累积。
 // 参考：https://blog.csdn.net/u013445530/article/details/44904017
 func (v *Vector) Rotate(angle float64) Vector {
 	x0 := float64(v.X)
@@ -118,8 +118,8 @@ func (v *Vector) Rotate(angle float64) Vector {
 	z := x0*sin + z0*cos
 
 	return Vector{
-		X: int32(x),
-		Z: int32(z),
+		X: roundInt32(x),
+		Z: roundInt32(z),
 	}
 }
 
@@ -158,12 +158,10 @@ func (v *Vector) GetAngle(vec *Vector) float64 {
 	return angle
 }
 
-// cross 计算以 p3 为基点的向量 p3→p1 与 p3→p2 的叉积。
-// 公式：(p1-p3) × (p2-p3)，正值表示 p1 在 p3→p2 的左侧。
-// 使用 int64 防止坐标差值乘法溢出。
+// cross 返回以 p3 为基点的向量 p3→p1 与 p3→p2 的叉积 (p1-p3) × (p2-p3) 的符号（-1、0 或 1）。
+// 叉积的值在大坐标下可能超出 int64 范围，而调用方只依赖其符号，因此委托 Orient2D 精确判定。
 func cross(p1, p2, p3 Coord) int64 {
-	s := int64(p1.X-p3.X)*int64(p2.Z-p3.Z) - int64(p2.X-p3.X)*int64(p1.Z-p3.Z)
-	return s
+	return int64(Orient2D(p3, p1, p2))
 }
 
 // CalCoordByRatio 沿 startCoord→endCoord 方向，按比例 ratio 计算插值坐标。